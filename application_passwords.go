@@ -0,0 +1,406 @@
+package gowprest
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ApplicationPassword is a credential for the REST API, created per
+// integration. Password is only sent once, by Create.
+type ApplicationPassword struct {
+	UUID     string `json:"uuid,omitempty"`
+	AppID    string `json:"app_id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Password string `json:"password,omitempty"`
+	Created  *Date  `json:"created,omitempty"`
+	LastUsed *Date  `json:"last_used,omitempty"`
+	LastIP   string `json:"last_ip,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type ApplicationPasswordData struct {
+	Name  string `json:"name,omitempty"`
+	AppID string `json:"app_id,omitempty"`
+}
+
+// ApplicationPasswords anchors the application passwords of one user.
+type ApplicationPasswords struct {
+	client   *RestClient
+	endpoint string
+}
+
+func (api *Users) ApplicationPasswords(userID int) *ApplicationPasswords {
+	return &ApplicationPasswords{
+		client:   api.client,
+		endpoint: "/wp/v2/users/" + strconv.Itoa(userID) + "/application-passwords",
+	}
+}
+
+// MyApplicationPasswords anchors the application passwords of the user the
+// client's credentials belong to.
+func (api *Users) MyApplicationPasswords() *ApplicationPasswords {
+	return &ApplicationPasswords{
+		client:   api.client,
+		endpoint: "/wp/v2/users/me/application-passwords",
+	}
+}
+
+type ListApplicationPasswords struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *ListApplicationPasswords) Clone() *ListApplicationPasswords {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListApplicationPasswords) With(options ...RequestOption) *ListApplicationPasswords {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *ApplicationPasswords) List() *ListApplicationPasswords {
+	return &ListApplicationPasswords{
+		endpoint: api.endpoint,
+		client:   api.client,
+	}
+}
+
+func (api *ListApplicationPasswords) Do() (passwords []ApplicationPassword, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&passwords).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return passwords, &wpError
+	}
+
+	return
+}
+
+type CreateApplicationPassword struct {
+	endpoint string
+	client   *RestClient
+	password ApplicationPasswordData
+}
+
+func (api *CreateApplicationPassword) Clone() *CreateApplicationPassword {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *CreateApplicationPassword) With(options ...RequestOption) *CreateApplicationPassword {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Create generates an application password; Name is required and must be
+// unique for the user.
+func (api *ApplicationPasswords) Create(password ApplicationPasswordData) *CreateApplicationPassword {
+	return &CreateApplicationPassword{
+		endpoint: api.endpoint,
+		client:   api.client,
+		password: password,
+	}
+}
+
+func (api *CreateApplicationPassword) Do() (password ApplicationPassword, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&password).
+		SetBody(api.password).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return password, &wpError
+	}
+
+	return
+}
+
+type RetrieveApplicationPassword struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *RetrieveApplicationPassword) Clone() *RetrieveApplicationPassword {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveApplicationPassword) With(options ...RequestOption) *RetrieveApplicationPassword {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *ApplicationPasswords) Retrieve(uuid string) *RetrieveApplicationPassword {
+	return &RetrieveApplicationPassword{
+		endpoint: api.endpoint + "/" + uuid,
+		client:   api.client,
+	}
+}
+
+// Introspect retrieves the application password the request is
+// authenticated with. It is only available on MyApplicationPasswords.
+func (api *ApplicationPasswords) Introspect() *RetrieveApplicationPassword {
+	return &RetrieveApplicationPassword{
+		endpoint: api.endpoint + "/introspect",
+		client:   api.client,
+	}
+}
+
+func (api *RetrieveApplicationPassword) Do() (password *ApplicationPassword, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&password).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return password, &wpError
+	}
+
+	return
+}
+
+type UpdateApplicationPassword struct {
+	endpoint string
+	client   *RestClient
+	password ApplicationPasswordData
+}
+
+func (api *UpdateApplicationPassword) Clone() *UpdateApplicationPassword {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdateApplicationPassword) With(options ...RequestOption) *UpdateApplicationPassword {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Update renames the application password; the password itself cannot be
+// changed.
+func (api *ApplicationPasswords) Update(uuid string, password ApplicationPasswordData) *UpdateApplicationPassword {
+	return &UpdateApplicationPassword{
+		endpoint: api.endpoint + "/" + uuid,
+		client:   api.client,
+		password: password,
+	}
+}
+
+func (api *UpdateApplicationPassword) Do() (password ApplicationPassword, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&password).
+		SetBody(api.password).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return password, &wpError
+	}
+
+	return
+}
+
+type DeleteApplicationPassword struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *DeleteApplicationPassword) Clone() *DeleteApplicationPassword {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeleteApplicationPassword) With(options ...RequestOption) *DeleteApplicationPassword {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Delete revokes the application password.
+func (api *ApplicationPasswords) Delete(uuid string) *DeleteApplicationPassword {
+	return &DeleteApplicationPassword{
+		endpoint: api.endpoint + "/" + uuid,
+		client:   api.client,
+	}
+}
+
+func (api *DeleteApplicationPassword) Do() (password ApplicationPassword, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		Delete(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return password, &wpError
+	}
+
+	var nested struct {
+		Deleted  bool                `json:"deleted"`
+		Previous ApplicationPassword `json:"previous"`
+	}
+	err = unmarshal(resp.Bytes(), &nested)
+	if err != nil {
+		return
+	}
+	return nested.Previous, nil
+}
+
+// ErrRotationUnverified is returned by RotateApplicationPassword when the
+// new password does not authenticate as itself. The new password has been
+// revoked again and the old one is still valid.
+var ErrRotationUnverified = errors.New("gowprest: rotated application password could not be verified")
+
+// RotateApplicationPassword replaces the application password the client
+// authenticates with by a new one.
+type RotateApplicationPassword struct {
+	client *RestClient
+	name   string
+}
+
+func (api *RotateApplicationPassword) Clone() *RotateApplicationPassword {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RotateApplicationPassword) With(options ...RequestOption) *RotateApplicationPassword {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// RotateApplicationPassword rotates the application password of the
+// client's Basic auth credentials.
+func (api *Users) RotateApplicationPassword() *RotateApplicationPassword {
+	return &RotateApplicationPassword{client: api.client}
+}
+
+// Name names the new password; it defaults to the name of the old one.
+func (api *RotateApplicationPassword) Name(name string) *RotateApplicationPassword {
+	api = api.Clone()
+	api.name = name
+	return api
+}
+
+// Do creates a new password, verifies it by introspecting it, then revokes
+// the old one. Clients are immutable, so the new credentials are returned
+// as a client derived from the rotating one, which must not be used
+// afterwards. If revoking the old password fails, client and password are
+// still returned along with the error, since the new password is valid.
+func (api *RotateApplicationPassword) Do() (client *RestClient, password ApplicationPassword, err error) {
+	passwords := api.client.Users().MyApplicationPasswords()
+
+	current, err := passwords.Introspect().Do()
+	if err != nil {
+		return
+	}
+
+	username := ""
+	if auth, isBasic := api.client.auth.(Authentication); isBasic {
+		username = auth.Username
+	} else {
+		var me *User
+		me, err = api.client.Users().Me().ContextEdit().Do()
+		if err != nil {
+			return
+		}
+		username = me.Username
+	}
+
+	// Names are unique per user, so the new password gets a temporary name
+	// while the old one exists.
+	name := cmp.Or(api.name, current.Name)
+	created, err := passwords.Create(ApplicationPasswordData{
+		Name:  fmt.Sprintf("%s (rotated %s)", name, time.Now().UTC().Format(time.RFC3339Nano)),
+		AppID: current.AppID,
+	}).Do()
+	if err != nil {
+		return
+	}
+
+	rotated := api.client.WithBasicAuth(username, created.Password)
+
+	verified, err := rotated.Users().MyApplicationPasswords().Introspect().Do()
+	if err != nil || verified.UUID != created.UUID {
+		passwords.Delete(created.UUID).Do()
+		err = errors.Join(ErrRotationUnverified, err)
+		return
+	}
+
+	client, password = rotated, created
+
+	myPasswords := rotated.Users().MyApplicationPasswords()
+	if _, err = myPasswords.Delete(current.UUID).Do(); err != nil {
+		return
+	}
+
+	renamed, err := myPasswords.Update(created.UUID, ApplicationPasswordData{Name: name}).Do()
+	if err != nil {
+		return
+	}
+
+	password.Name = renamed.Name
+	return
+}
@@ -1 +1 @@
-package gowprest
+package gowprest
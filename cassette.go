@@ -0,0 +1,391 @@
+package gowprest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// CassetteMode selects whether a cassette records live traffic or replays it.
+type CassetteMode int
+
+const (
+	// CassetteRecord sends every request to the site and appends the
+	// interaction to the cassette file, replacing what it held before.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay answers every request from the cassette file without
+	// touching the network.
+	CassetteReplay
+)
+
+// ErrCassetteMiss is returned in replay mode for a request the cassette holds
+// no interaction for.
+var ErrCassetteMiss = errors.New("gowprest: request not found in cassette")
+
+const redacted = "[REDACTED]"
+
+// redactedHeaders and redactedParams hold credentials that never reach a
+// cassette file.
+var (
+	redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-WP-Nonce"}
+	redactedParams  = []string{"_wpnonce", "password"}
+)
+
+// maxRecordedBody is the size up to which a textual request body is written
+// to the cassette as is.
+const maxRecordedBody = 64 << 10
+
+// CassetteRequest is the recorded side of an interaction. Small textual
+// bodies are kept in Body; larger or binary ones, such as uploads, only by
+// their BodySize and, when they can be read again without consuming the
+// request, their BodySHA256. BodySize is -1 for a streamed body of unknown
+// length.
+type CassetteRequest struct {
+	Method     string      `json:"method"`
+	Route      string      `json:"route"`
+	Query      string      `json:"query"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body,omitempty"`
+	BodySize   int64       `json:"body_size,omitempty"`
+	BodySHA256 string      `json:"body_sha256,omitempty"`
+}
+
+// CassetteResponse is the replayed side of an interaction. Textual bodies
+// are kept readable in Body; binary ones, such as downloaded images, in
+// BodyBase64 so they replay byte for byte.
+type CassetteResponse struct {
+	Status     int         `json:"status"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+	BodyBase64 []byte      `json:"body_base64,omitempty"`
+}
+
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// WithCassette records the client's traffic to the cassette file at path, or
+// replays it from there, depending on mode. Requests are matched by method,
+// route and query with its arguments sorted; the host is ignored, so a
+// cassette recorded against one site replays against any base URL.
+// Identical requests are answered by their recordings in order, and a request
+// without a recording fails with ErrCassetteMiss.
+//
+// Credentials sent in headers, the _wpnonce and password query arguments and
+// password fields of request and response bodies are redacted before
+// anything is written, so replayed responses carry the placeholder instead.
+func (api *RestClient) WithCassette(path string, mode CassetteMode) *RestClient {
+	// The cassette sits below the compatibility transport, so it records
+	// requests as they are sent on the wire.
+	cassette := &cassetteTransport{
+		next: api.httpClient.Transport().(*compatibilityTransport).next,
+		path: path,
+		mode: mode,
+	}
+
+	if mode == CassetteReplay {
+		cassette.err = cassette.load()
+	}
+
+	derived := api.derive()
+	derived.cassette = cassette
+	return derived
+}
+
+type cassetteKey struct{}
+
+type cassetteTransport struct {
+	next http.RoundTripper
+	path string
+	mode CassetteMode
+
+	mu       sync.Mutex
+	err      error
+	cassette Cassette
+	played   []bool
+}
+
+func (t *cassetteTransport) load() error {
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		return fmt.Errorf("gowprest: load cassette: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &t.cassette); err != nil {
+		return fmt.Errorf("gowprest: load cassette %s: %w", t.path, err)
+	}
+
+	t.played = make([]bool, len(t.cassette.Interactions))
+	return nil
+}
+
+func (t *cassetteTransport) save() error {
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.path, data, 0o644)
+}
+
+// RoundTrip sends req, or replays it, without holding the cassette lock
+// during network I/O, so concurrent requests of a client stay concurrent.
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.err != nil {
+		closeBody(req)
+		return nil, t.err
+	}
+
+	recorded, err := recordRequest(req)
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+
+	if t.mode == CassetteReplay {
+		// The request is answered locally, but its body is still read to
+		// the end, as the network would, so upload progress is reported.
+		if req.Body != nil {
+			_, err = io.Copy(io.Discard, req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return t.replay(req, recorded)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recording := CassetteResponse{
+		Status:  resp.StatusCode,
+		Headers: redactHeaders(resp.Header),
+	}
+
+	// A JSON string can only hold UTF-8, so anything else is stored as is
+	// and encoded in base64.
+	contentType := resp.Header.Get("Content-Type")
+	if textual(contentType) && utf8.Valid(body) {
+		recording.Body = redactBody(body, contentType)
+	} else {
+		recording.BodyBase64 = body
+	}
+
+	interaction := CassetteInteraction{
+		Request:  recorded,
+		Response: recording,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	if err := t.save(); err != nil {
+		return nil, fmt.Errorf("gowprest: save cassette: %w", err)
+	}
+
+	return resp, nil
+}
+
+// closeBody closes the body of a request that is not sent, as a
+// RoundTripper must.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+func (t *cassetteTransport) replay(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		if t.played[i] {
+			continue
+		}
+
+		if interaction.Request.Method != recorded.Method ||
+			interaction.Request.Route != recorded.Route ||
+			interaction.Request.Query != recorded.Query {
+			continue
+		}
+
+		t.played[i] = true
+		recording := interaction.Response
+
+		body := []byte(recording.Body)
+		if recording.BodyBase64 != nil {
+			body = recording.BodyBase64
+		}
+
+		// Redaction may have changed the body length.
+		headers := recording.Headers.Clone()
+		headers.Del("Content-Length")
+
+		return &http.Response{
+			Status:        strconv.Itoa(recording.Status) + " " + http.StatusText(recording.Status),
+			StatusCode:    recording.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        headers,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s?%s", ErrCassetteMiss, recorded.Method, recorded.Route, recorded.Query)
+}
+
+// recordRequest captures the parts of req a cassette stores and matches on,
+// with credentials redacted. It never reads req.Body itself: the body is
+// only read through GetBody, which returns a fresh copy.
+func recordRequest(req *http.Request) (recorded CassetteRequest, err error) {
+	recorded = CassetteRequest{
+		Method:  req.Method,
+		Route:   req.URL.Path,
+		Headers: redactHeaders(req.Header),
+	}
+
+	query := req.URL.Query()
+	for _, param := range redactedParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	// Encode sorts the arguments by key, which normalizes their order.
+	recorded.Query = query.Encode()
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+
+	recorded.BodySize = req.ContentLength
+	if req.GetBody == nil {
+		return
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	contentType := req.Header.Get("Content-Type")
+	if textual(contentType) && req.ContentLength >= 0 && req.ContentLength <= maxRecordedBody {
+		var data []byte
+		data, err = io.ReadAll(body)
+		if err != nil {
+			return
+		}
+		recorded.Body = redactBody(data, contentType)
+		recorded.BodySize = 0
+		return
+	}
+
+	hash := sha256.New()
+	recorded.BodySize, err = io.Copy(hash, body)
+	if err != nil {
+		return
+	}
+	recorded.BodySHA256 = hex.EncodeToString(hash.Sum(nil))
+	return
+}
+
+// textual reports whether a body of contentType is worth keeping readable
+// in a cassette.
+func textual(contentType string) bool {
+	return contentType == "" ||
+		strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "x-www-form-urlencoded")
+}
+
+func redactHeaders(headers http.Header) http.Header {
+	clean := headers.Clone()
+	for _, key := range redactedHeaders {
+		if clean.Get(key) != "" {
+			clean.Set(key, redacted)
+		}
+	}
+	return clean
+}
+
+// redactJSON replaces credential fields at any depth of a JSON document, such
+// as the password returned once when an application password is created.
+func redactJSON(document any) any {
+	switch document := document.(type) {
+	case map[string]any:
+		for key, value := range document {
+			if slices.Contains(redactedParams, key) {
+				document[key] = redacted
+				continue
+			}
+			document[key] = redactJSON(value)
+		}
+	case []any:
+		for i, value := range document {
+			document[i] = redactJSON(value)
+		}
+	}
+	return document
+}
+
+func redactBody(body []byte, contentType string) string {
+	switch {
+	case strings.Contains(contentType, "json"):
+		var document any
+		if json.Unmarshal(body, &document) != nil {
+			return string(body)
+		}
+		clean, _ := json.Marshal(redactJSON(document))
+		return string(clean)
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		for _, param := range redactedParams {
+			if form.Has(param) {
+				form.Set(param, redacted)
+			}
+		}
+		return form.Encode()
+	}
+
+	return string(body)
+}
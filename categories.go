@@ -1,379 +1,622 @@
-package gowprest
-
-import (
-	"encoding/json"
-	"strconv"
-	"strings"
-)
-
-type Category struct {
-	ID          int    `json:"id,omitempty"`
-	Count       int    `json:"count,omitempty"`
-	Description string `json:"description,omitempty"`
-	Link        string `json:"link,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Slug        string `json:"slug,omitempty"`
-	Taxonomy    string `json:"taxonomy,omitempty"`
-	Parent      int    `json:"parent,omitempty"`
-	Meta        any    `json:"meta,omitempty"`
-}
-
-type CategoryData struct {
-	ID          int    `json:"id,omitempty"`
-	Description string `json:"description,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Slug        string `json:"slug,omitempty"`
-	Parent      int    `json:"parent,omitempty"`
-	Meta        any    `json:"meta,omitempty"`
-}
-
-type Categories struct {
-	client *RestClient
-}
-
-func (c *RestClient) Categories() *Categories {
-	return &Categories{client: c}
-}
-
-type ListCategories struct {
-	endpoint  string
-	client    *RestClient
-	arguments map[string]string
-}
-
-func (api *Categories) List() *ListCategories {
-	return &ListCategories{
-		endpoint:  "/wp/v2/categories",
-		client:    api.client,
-		arguments: make(map[string]string),
-	}
-}
-
-func (api *ListCategories) ContextView() *ListCategories {
-	api.arguments["context"] = "view"
-	return api
-}
-
-func (api *ListCategories) ContextEdit() *ListCategories {
-	api.arguments["context"] = "edit"
-	return api
-}
-
-func (api *ListCategories) ContextEmbed() *ListCategories {
-	api.arguments["context"] = "embed"
-	return api
-}
-
-func (api *ListCategories) Page(page int) *ListCategories {
-	api.arguments["page"] = strconv.Itoa(page)
-	return api
-}
-
-func (api *ListCategories) PerPage(perPage int) *ListCategories {
-	api.arguments["per_page"] = strconv.Itoa(perPage)
-	return api
-}
-
-func (api *ListCategories) Search(query string) *ListCategories {
-	api.arguments["search"] = query
-	return api
-}
-
-func (api *ListCategories) Exclude(excludeIDs ...int) *ListCategories {
-	excludes := []string{}
-	for _, excludeId := range excludeIDs {
-		excludes = append(excludes, strconv.Itoa(excludeId))
-	}
-	api.arguments["exclude"] = strings.Join(excludes, ",")
-	return api
-}
-
-func (api *ListCategories) Include(includeIDs ...int) *ListCategories {
-	includes := []string{}
-	for _, includeId := range includeIDs {
-		includes = append(includes, strconv.Itoa(includeId))
-	}
-	api.arguments["include"] = strings.Join(includes, ",")
-	return api
-}
-
-func (api *ListCategories) OrderAsc() *ListCategories {
-	api.arguments["order"] = "asc"
-	return api
-}
-
-func (api *ListCategories) OrderDesc() *ListCategories {
-	api.arguments["order"] = "desc"
-	return api
-}
-
-func (api *ListCategories) OrderById() *ListCategories {
-	api.arguments["orderby"] = "id"
-	return api
-}
-
-func (api *ListCategories) OrderByInclude() *ListCategories {
-	api.arguments["orderby"] = "include"
-	return api
-}
-
-func (api *ListCategories) OrderByName() *ListCategories {
-	api.arguments["orderby"] = "name"
-	return api
-}
-
-func (api *ListCategories) OrderBySlug() *ListCategories {
-	api.arguments["orderby"] = "slug"
-	return api
-}
-
-func (api *ListCategories) OrderByIncludeSlug() *ListCategories {
-	api.arguments["orderby"] = "include_slugs"
-	return api
-}
-
-func (api *ListCategories) OrderByTermGroup() *ListCategories {
-	api.arguments["orderby"] = "term_group"
-	return api
-}
-
-func (api *ListCategories) OrderByDescription() *ListCategories {
-	api.arguments["orderby"] = "description"
-	return api
-}
-
-func (api *ListCategories) OrderByCount() *ListCategories {
-	api.arguments["orderby"] = "count"
-	return api
-}
-
-func (api *ListCategories) HideEmpty(hide bool) *ListCategories {
-	api.arguments["hide_empty"] = strconv.FormatBool(hide)
-	return api
-}
-
-func (api *ListCategories) Parent(parentID int) *ListCategories {
-	api.arguments["parent"] = strconv.Itoa(parentID)
-	return api
-}
-
-func (api *ListCategories) Post(postID int) *ListCategories {
-	api.arguments["post"] = strconv.Itoa(postID)
-	return api
-}
-
-func (api *ListCategories) Slug(slugs ...string) *ListCategories {
-	api.arguments["slug"] = strings.Join(slugs, ",")
-	return api
-}
-
-func (api *ListCategories) Do() (categories []Category, err error) {
-	_, err = api.client.httpClient.R().
-		SetHeader("Accept", "application/json").
-		SetResult(&categories).
-		SetQueryParams(api.arguments).
-		Get(api.client.endpoint + api.endpoint)
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type CreateCategory struct {
-	endpoint string
-	client   *RestClient
-	category CategoryData
-}
-
-func (api *Categories) Create(category CategoryData) *CreateCategory {
-	return &CreateCategory{
-		endpoint: "/wp/v2/categories",
-		client:   api.client,
-		category: category,
-	}
-}
-
-func (api *CreateCategory) Do() (category Category, err error) {
-	resp, err := api.client.httpClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-		SetResult(&category).
-		SetBody(api.category).
-		Post(api.client.endpoint + api.endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return category, &wpError
-	}
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type RetrieveCategory struct {
-	endpoint  string
-	client    *RestClient
-	arguments map[string]string
-}
-
-func (api *Categories) Retrieve(categoryId int) *RetrieveCategory {
-	return &RetrieveCategory{
-		endpoint:  "/wp/v2/categories/" + strconv.Itoa(categoryId),
-		client:    api.client,
-		arguments: make(map[string]string),
-	}
-}
-
-func (api *RetrieveCategory) ContextView() *RetrieveCategory {
-	api.arguments["context"] = "view"
-	return api
-}
-
-func (api *RetrieveCategory) ContextEdit() *RetrieveCategory {
-	api.arguments["context"] = "edit"
-	return api
-}
-
-func (api *RetrieveCategory) ContextEmbed() *RetrieveCategory {
-	api.arguments["context"] = "embed"
-	return api
-}
-
-func (api *RetrieveCategory) Do() (category *Category, err error) {
-	endpoint := api.client.endpoint + api.endpoint
-
-	restyClient := api.client.httpClient.R()
-	if api.client.auth.Username != "" && api.client.auth.Password != "" && api.arguments["context"] == "edit" {
-		restyClient.SetBasicAuth(api.client.auth.Username, api.client.auth.Password)
-	}
-
-	resp, err := restyClient.
-		SetHeader("Accept", "application/json").
-		SetResult(&category).
-		SetQueryParams(api.arguments).
-		Get(endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return category, &wpError
-	}
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type UpdateCategory struct {
-	endpoint string
-	client   *RestClient
-	category CategoryData
-}
-
-func (api *Categories) Update(category CategoryData) *UpdateCategory {
-	return &UpdateCategory{
-		endpoint: "/wp/v2/categories/" + strconv.Itoa(category.ID),
-		client:   api.client,
-		category: category,
-	}
-}
-
-func (api *UpdateCategory) Do() (category Category, err error) {
-	resp, err := api.client.httpClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-		SetResult(&category).
-		SetBody(api.category).
-		Post(api.client.endpoint + api.endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return category, &wpError
-	}
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type DeleteCategory struct {
-	endpoint   string
-	client     *RestClient
-	categoryId int
-	force      bool
-}
-
-func (api *Categories) Delete(categoryId int) *DeleteCategory {
-	return &DeleteCategory{
-		endpoint:   "/wp/v2/categories",
-		client:     api.client,
-		categoryId: categoryId,
-	}
-}
-
-func (api *DeleteCategory) Force() *DeleteCategory {
-	api.force = true
-	return api
-}
-
-func (api *DeleteCategory) Do() (category Category, err error) {
-	endpoint := api.client.endpoint + api.endpoint + "/" + strconv.Itoa(api.categoryId)
-	resp, err :=
-		api.client.httpClient.R().
-			SetHeader("Content-Type", "application/json").
-			SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-			SetQueryParam("force", strconv.FormatBool(api.force)).
-			Delete(endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return category, &wpError
-	}
-
-	if api.force {
-		var nested struct {
-			Deleted  bool     `json:"deleted"`
-			Previous Category `json:"previous"`
-		}
-		err = json.Unmarshal(resp.Bytes(), &nested)
-		if err != nil {
-			return
-		}
-		return nested.Previous, nil
-	}
-
-	err = json.Unmarshal(resp.Bytes(), &category)
-	return
-}
+package gowprest
+
+import (
+	"encoding/json"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+type Category struct {
+	ID          int    `json:"id,omitempty"`
+	Count       int    `json:"count,omitempty"`
+	Description string `json:"description,omitempty"`
+	Link        string `json:"link,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	Taxonomy    string `json:"taxonomy,omitempty"`
+	Parent      int    `json:"parent,omitempty"`
+	Meta        any    `json:"meta,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type CategoryData struct {
+	ID          int    `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	Parent      int    `json:"parent,omitempty"`
+	Meta        any    `json:"meta,omitempty"`
+}
+
+type Categories struct {
+	client *RestClient
+}
+
+func (c *RestClient) Categories() *Categories {
+	return &Categories{client: c}
+}
+
+type ListCategories struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *ListCategories) Clone() *ListCategories {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListCategories) With(options ...RequestOption) *ListCategories {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Categories) List() *ListCategories {
+	return &ListCategories{
+		endpoint:  "/wp/v2/categories",
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *ListCategories) ContextView() *ListCategories {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *ListCategories) ContextEdit() *ListCategories {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *ListCategories) ContextEmbed() *ListCategories {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *ListCategories) Page(page int) *ListCategories {
+	api = api.Clone()
+	api.arguments["page"] = strconv.Itoa(page)
+	return api
+}
+
+func (api *ListCategories) PerPage(perPage int) *ListCategories {
+	api = api.Clone()
+	api.arguments["per_page"] = strconv.Itoa(perPage)
+	return api
+}
+
+func (api *ListCategories) Search(query string) *ListCategories {
+	api = api.Clone()
+	api.arguments["search"] = query
+	return api
+}
+
+func (api *ListCategories) Exclude(excludeIDs ...int) *ListCategories {
+	api = api.Clone()
+	excludes := []string{}
+	for _, excludeId := range excludeIDs {
+		excludes = append(excludes, strconv.Itoa(excludeId))
+	}
+	api.arguments["exclude"] = strings.Join(excludes, ",")
+	return api
+}
+
+func (api *ListCategories) Include(includeIDs ...int) *ListCategories {
+	api = api.Clone()
+	includes := []string{}
+	for _, includeId := range includeIDs {
+		includes = append(includes, strconv.Itoa(includeId))
+	}
+	api.arguments["include"] = strings.Join(includes, ",")
+	return api
+}
+
+func (api *ListCategories) OrderAsc() *ListCategories {
+	api = api.Clone()
+	api.arguments["order"] = "asc"
+	return api
+}
+
+func (api *ListCategories) OrderDesc() *ListCategories {
+	api = api.Clone()
+	api.arguments["order"] = "desc"
+	return api
+}
+
+func (api *ListCategories) OrderById() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "id"
+	return api
+}
+
+func (api *ListCategories) OrderByInclude() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "include"
+	return api
+}
+
+func (api *ListCategories) OrderByName() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "name"
+	return api
+}
+
+func (api *ListCategories) OrderBySlug() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "slug"
+	return api
+}
+
+func (api *ListCategories) OrderByIncludeSlug() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "include_slugs"
+	return api
+}
+
+func (api *ListCategories) OrderByTermGroup() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "term_group"
+	return api
+}
+
+func (api *ListCategories) OrderByDescription() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "description"
+	return api
+}
+
+func (api *ListCategories) OrderByCount() *ListCategories {
+	api = api.Clone()
+	api.arguments["orderby"] = "count"
+	return api
+}
+
+func (api *ListCategories) HideEmpty(hide bool) *ListCategories {
+	api = api.Clone()
+	api.arguments["hide_empty"] = strconv.FormatBool(hide)
+	return api
+}
+
+func (api *ListCategories) Parent(parentID int) *ListCategories {
+	api = api.Clone()
+	api.arguments["parent"] = strconv.Itoa(parentID)
+	return api
+}
+
+func (api *ListCategories) Post(postID int) *ListCategories {
+	api = api.Clone()
+	api.arguments["post"] = strconv.Itoa(postID)
+	return api
+}
+
+func (api *ListCategories) Slug(slugs ...string) *ListCategories {
+	api = api.Clone()
+	api.arguments["slug"] = strings.Join(slugs, ",")
+	return api
+}
+
+func (api *ListCategories) Do() (categories []Category, err error) {
+	_, err = api.client.request().
+		SetHeader("Accept", "application/json").
+		SetResult(&categories).
+		SetQueryParams(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+type CreateCategory struct {
+	endpoint string
+	client   *RestClient
+	category CategoryData
+}
+
+func (api *CreateCategory) Clone() *CreateCategory {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *CreateCategory) With(options ...RequestOption) *CreateCategory {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Categories) Create(category CategoryData) *CreateCategory {
+	return &CreateCategory{
+		endpoint: "/wp/v2/categories",
+		client:   api.client,
+		category: category,
+	}
+}
+
+func (api *CreateCategory) Do() (category Category, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&category).
+		SetBody(api.category).
+		Post(api.client.url(api.endpoint))
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return category, &wpError
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+type RetrieveCategory struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *RetrieveCategory) Clone() *RetrieveCategory {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveCategory) With(options ...RequestOption) *RetrieveCategory {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Categories) Retrieve(categoryId int) *RetrieveCategory {
+	return &RetrieveCategory{
+		endpoint:  "/wp/v2/categories/" + strconv.Itoa(categoryId),
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *RetrieveCategory) ContextView() *RetrieveCategory {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *RetrieveCategory) ContextEdit() *RetrieveCategory {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *RetrieveCategory) ContextEmbed() *RetrieveCategory {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *RetrieveCategory) Do() (category *Category, err error) {
+	endpoint := api.client.url(api.endpoint)
+
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&category).
+		SetQueryParams(api.arguments).
+		Get(endpoint)
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return category, &wpError
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+type UpdateCategory struct {
+	endpoint string
+	client   *RestClient
+	category CategoryData
+}
+
+func (api *UpdateCategory) Clone() *UpdateCategory {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdateCategory) With(options ...RequestOption) *UpdateCategory {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Categories) Update(category CategoryData) *UpdateCategory {
+	return &UpdateCategory{
+		endpoint: "/wp/v2/categories/" + strconv.Itoa(category.ID),
+		client:   api.client,
+		category: category,
+	}
+}
+
+func (api *UpdateCategory) Do() (category Category, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&category).
+		SetBody(api.category).
+		Post(api.client.url(api.endpoint))
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return category, &wpError
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// PatchCategory updates only the fields set on it, so the description can be
+// emptied or the parent reset to 0.
+type PatchCategory struct {
+	endpoint string
+	client   *RestClient
+	fields   map[string]any
+}
+
+func (api *PatchCategory) Clone() *PatchCategory {
+	clone := *api
+	clone.fields = cloneFields(api.fields)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *PatchCategory) With(options ...RequestOption) *PatchCategory {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Categories) Patch(categoryID int) *PatchCategory {
+	return &PatchCategory{
+		endpoint: "/wp/v2/categories/" + strconv.Itoa(categoryID),
+		client:   api.client,
+		fields:   make(map[string]any),
+	}
+}
+
+// Set sends value for the field as is; a nil value is sent as null.
+func (api *PatchCategory) Set(field string, value any) *PatchCategory {
+	api = api.Clone()
+	api.fields[field] = value
+	return api
+}
+
+func (api *PatchCategory) Name(name string) *PatchCategory {
+	return api.Set("name", name)
+}
+
+func (api *PatchCategory) Slug(slug string) *PatchCategory {
+	return api.Set("slug", slug)
+}
+
+func (api *PatchCategory) Description(description string) *PatchCategory {
+	return api.Set("description", description)
+}
+
+func (api *PatchCategory) Parent(parentID int) *PatchCategory {
+	return api.Set("parent", parentID)
+}
+
+// Meta sets a single meta key; nil deletes it.
+func (api *PatchCategory) Meta(key string, value any) *PatchCategory {
+	api = api.Clone()
+	meta, _ := api.fields["meta"].(map[string]any)
+	if meta == nil {
+		meta = make(map[string]any)
+		api.fields["meta"] = meta
+	}
+	meta[key] = value
+	return api
+}
+
+func (api *PatchCategory) Do() (category Category, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&category).
+		SetBody(api.fields).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return category, &wpError
+	}
+
+	return
+}
+
+// UpsertCategory creates the category or updates the one with the same slug.
+type UpsertCategory struct {
+	client      *RestClient
+	category    CategoryData
+	matchParent bool
+}
+
+func (api *UpsertCategory) Clone() *UpsertCategory {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpsertCategory) With(options ...RequestOption) *UpsertCategory {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Upsert creates or updates category, matched by category.Slug.
+func (api *Categories) Upsert(category CategoryData) *UpsertCategory {
+	return &UpsertCategory{
+		client:   api.client,
+		category: category,
+	}
+}
+
+// MatchParent only takes a category under category.Parent as the match.
+func (api *UpsertCategory) MatchParent() *UpsertCategory {
+	api = api.Clone()
+	api.matchParent = true
+	return api
+}
+
+// Do reports with created whether the category was created or updated.
+func (api *UpsertCategory) Do() (category Category, created bool, err error) {
+	data := api.category
+	if data.Slug == "" {
+		err = errSlugRequired
+		return
+	}
+
+	query := map[string]string{"slug": data.Slug}
+	if api.matchParent {
+		query["parent"] = strconv.Itoa(data.Parent)
+	}
+
+	matches, err := findBySlug[Category](api.client, "/wp/v2/categories", query)
+	if err != nil {
+		return
+	}
+
+	switch len(matches) {
+	case 0:
+		category, err = api.client.Categories().Create(data).Do()
+		return category, err == nil, err
+	case 1:
+		data.ID = matches[0].ID
+		category, err = api.client.Categories().Update(data).Do()
+		return
+	}
+
+	err = ErrAmbiguousSlug
+	return
+}
+
+type DeleteCategory struct {
+	endpoint   string
+	client     *RestClient
+	categoryId int
+	force      bool
+}
+
+func (api *DeleteCategory) Clone() *DeleteCategory {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeleteCategory) With(options ...RequestOption) *DeleteCategory {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Categories) Delete(categoryId int) *DeleteCategory {
+	return &DeleteCategory{
+		endpoint:   "/wp/v2/categories",
+		client:     api.client,
+		categoryId: categoryId,
+	}
+}
+
+func (api *DeleteCategory) Force() *DeleteCategory {
+	api = api.Clone()
+	api.force = true
+	return api
+}
+
+func (api *DeleteCategory) Do() (category Category, err error) {
+	endpoint := api.client.url(api.endpoint + "/" + strconv.Itoa(api.categoryId))
+	resp, err :=
+		api.client.authenticate(api.client.request()).
+			SetHeader("Content-Type", "application/json").
+			SetQueryParam("force", strconv.FormatBool(api.force)).
+			Delete(endpoint)
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return category, &wpError
+	}
+
+	if api.force {
+		var nested struct {
+			Deleted  bool     `json:"deleted"`
+			Previous Category `json:"previous"`
+		}
+		err = unmarshal(resp.Bytes(), &nested)
+		if err != nil {
+			return
+		}
+		return nested.Previous, nil
+	}
+
+	err = unmarshal(resp.Bytes(), &category)
+	return
+}
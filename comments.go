@@ -1,448 +1,656 @@
-package gowprest
-
-import (
-	"encoding/json"
-	"strconv"
-	"strings"
-	"time"
-)
-
-type Comment struct {
-	ID               int               `json:"id,omitempty"`
-	Post             int               `json:"post,omitempty"`
-	Parent           int               `json:"parent,omitempty"`
-	Author           int               `json:"author,omitempty"`
-	AuthorName       string            `json:"author_name,omitempty"`
-	AuthorEmail      string            `json:"author_email,omitempty"`
-	AuthorURL        string            `json:"author_url,omitempty"`
-	AuthorIP         string            `json:"author_ip,omitempty"`
-	AuthorUserAgent  string            `json:"author_user_agent,omitempty"`
-	Date             *Date             `json:"date,omitempty"`
-	DateGMT          *Date             `json:"date_gmt,omitempty"`
-	Content          *Object           `json:"content,omitempty"`
-	Link             string            `json:"link,omitempty"`
-	Status           string            `json:"status,omitempty"`
-	Type             string            `json:"type,omitempty"`
-	AuthorAvatarURLs map[string]string `json:"author_avatar_urls,omitempty"`
-	Meta             map[string]any    `json:"meta,omitempty"`
-}
-
-type CommentData struct {
-	ID              int            `json:"id,omitempty"`
-	Post            int            `json:"post,omitempty"`
-	Parent          int            `json:"parent,omitempty"`
-	Author          int            `json:"author,omitempty"`
-	AuthorName      string         `json:"author_name,omitempty"`
-	AuthorEmail     string         `json:"author_email,omitempty"`
-	AuthorURL       string         `json:"author_url,omitempty"`
-	AuthorIP        string         `json:"author_ip,omitempty"`
-	AuthorUserAgent string         `json:"author_user_agent,omitempty"`
-	Date            *Date          `json:"date,omitempty"`
-	DateGMT         *Date          `json:"date_gmt,omitempty"`
-	Content         string         `json:"content,omitempty"`
-	Status          string         `json:"status,omitempty"`
-	Meta            map[string]any `json:"meta,omitempty"`
-}
-
-type DeletedComment struct {
-	Previous Comment `json:"previous"`
-	Deleted  bool    `json:"deleted"`
-}
-
-type Comments struct {
-	client *RestClient
-}
-
-func (c *RestClient) Comments() *Comments {
-	return &Comments{client: c}
-}
-
-type ListComments struct {
-	endpoint  string
-	client    *RestClient
-	arguments map[string]string
-}
-
-func (api *Comments) List() *ListComments {
-	return &ListComments{
-		endpoint:  "/wp/v2/comments",
-		client:    api.client,
-		arguments: make(map[string]string),
-	}
-}
-
-func (api *ListComments) ContextView() *ListComments {
-	api.arguments["context"] = "view"
-	return api
-}
-
-func (api *ListComments) ContextEdit() *ListComments {
-	api.arguments["context"] = "edit"
-	return api
-}
-
-func (api *ListComments) ContextEmbed() *ListComments {
-	api.arguments["context"] = "embed"
-	return api
-}
-
-func (api *ListComments) Page(page int) *ListComments {
-	api.arguments["page"] = strconv.Itoa(page)
-	return api
-}
-
-func (api *ListComments) PerPage(perPage int) *ListComments {
-	api.arguments["per_page"] = strconv.Itoa(perPage)
-	return api
-}
-
-func (api *ListComments) Search(query string) *ListComments {
-	api.arguments["search"] = query
-	return api
-}
-
-func (api *ListComments) After(after time.Time) *ListComments {
-	api.arguments["after"] = after.Format(time.RFC3339)
-	return api
-}
-
-func (api *ListComments) Before(before time.Time) *ListComments {
-	api.arguments["before"] = before.Format(time.RFC3339)
-	return api
-}
-
-func (api *ListComments) Author(authorIDs ...int) *ListComments {
-	authors := []string{}
-	for _, id := range authorIDs {
-		authors = append(authors, strconv.Itoa(id))
-	}
-	api.arguments["author"] = strings.Join(authors, ",")
-	return api
-}
-
-func (api *ListComments) AuthorExclude(authorIDs ...int) *ListComments {
-	authors := []string{}
-	for _, id := range authorIDs {
-		authors = append(authors, strconv.Itoa(id))
-	}
-	api.arguments["author_exclude"] = strings.Join(authors, ",")
-	return api
-}
-
-func (api *ListComments) AuthorEmail(email string) *ListComments {
-	api.arguments["author_email"] = email
-	return api
-}
-
-func (api *ListComments) Exclude(excludeIDs ...int) *ListComments {
-	excludes := []string{}
-	for _, id := range excludeIDs {
-		excludes = append(excludes, strconv.Itoa(id))
-	}
-	api.arguments["exclude"] = strings.Join(excludes, ",")
-	return api
-}
-
-func (api *ListComments) Include(includeIDs ...int) *ListComments {
-	includes := []string{}
-	for _, id := range includeIDs {
-		includes = append(includes, strconv.Itoa(id))
-	}
-	api.arguments["include"] = strings.Join(includes, ",")
-	return api
-}
-
-func (api *ListComments) Offset(offset int) *ListComments {
-	api.arguments["offset"] = strconv.Itoa(offset)
-	return api
-}
-
-func (api *ListComments) OrderAsc() *ListComments {
-	api.arguments["order"] = "asc"
-	return api
-}
-
-func (api *ListComments) OrderDesc() *ListComments {
-	api.arguments["order"] = "desc"
-	return api
-}
-
-func (api *ListComments) OrderByDate() *ListComments {
-	api.arguments["orderby"] = "date"
-	return api
-}
-
-func (api *ListComments) OrderByID() *ListComments {
-	api.arguments["orderby"] = "id"
-	return api
-}
-
-func (api *ListComments) OrderByInclude() *ListComments {
-	api.arguments["orderby"] = "include"
-	return api
-}
-
-func (api *ListComments) OrderByPost() *ListComments {
-	api.arguments["orderby"] = "post"
-	return api
-}
-
-func (api *ListComments) OrderByParent() *ListComments {
-	api.arguments["orderby"] = "parent"
-	return api
-}
-
-func (api *ListComments) OrderByCommentType() *ListComments {
-	api.arguments["orderby"] = "type"
-	return api
-}
-
-func (api *ListComments) Parent(parentIDs ...int) *ListComments {
-	parents := []string{}
-	for _, id := range parentIDs {
-		parents = append(parents, strconv.Itoa(id))
-	}
-	api.arguments["parent"] = strings.Join(parents, ",")
-	return api
-}
-
-func (api *ListComments) ParentExclude(parentIDs ...int) *ListComments {
-	parents := []string{}
-	for _, id := range parentIDs {
-		parents = append(parents, strconv.Itoa(id))
-	}
-	api.arguments["parent_exclude"] = strings.Join(parents, ",")
-	return api
-}
-
-func (api *ListComments) Post(postIDs ...int) *ListComments {
-	posts := []string{}
-	for _, id := range postIDs {
-		posts = append(posts, strconv.Itoa(id))
-	}
-	api.arguments["post"] = strings.Join(posts, ",")
-	return api
-}
-
-func (api *ListComments) Status(status string) *ListComments {
-	api.arguments["status"] = status
-	return api
-}
-
-func (api *ListComments) Type(commentType string) *ListComments {
-	api.arguments["type"] = commentType
-	return api
-}
-
-func (api *ListComments) Do() (comments []Comment, err error) {
-	restyClient := api.client.httpClient.R()
-	if api.client.auth.Username != "" && api.client.auth.Password != "" && api.arguments["context"] == "edit" {
-		restyClient.SetBasicAuth(api.client.auth.Username, api.client.auth.Password)
-	}
-
-	resp, err := restyClient.
-		SetHeader("Accept", "application/json").
-		SetResult(&comments).
-		SetQueryParams(api.arguments).
-		Get(api.client.endpoint + api.endpoint)
-
-	if err != nil {
-		return
-	}
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-		if err != nil {
-			return
-		}
-		return comments, &wpError
-	}
-
-	return
-}
-
-type CreateComment struct {
-	endpoint string
-	client   *RestClient
-	comment  CommentData
-}
-
-func (api *Comments) Create(comment CommentData) *CreateComment {
-	return &CreateComment{
-		endpoint: "/wp/v2/comments",
-		client:   api.client,
-		comment:  comment,
-	}
-}
-
-func (api *CreateComment) Do() (comment Comment, err error) {
-	resp, err := api.client.httpClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-		SetResult(&comment).
-		SetBody(api.comment).
-		Post(api.client.endpoint + api.endpoint)
-
-	if err != nil {
-		return
-	}
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-		if err != nil {
-			return
-		}
-		return comment, &wpError
-	}
-
-	return
-}
-
-type RetrieveComment struct {
-	endpoint  string
-	client    *RestClient
-	arguments map[string]string
-}
-
-func (api *Comments) Retrieve(commentID int) *RetrieveComment {
-	return &RetrieveComment{
-		endpoint:  "/wp/v2/comments/" + strconv.Itoa(commentID),
-		client:    api.client,
-		arguments: make(map[string]string),
-	}
-}
-
-func (api *RetrieveComment) ContextView() *RetrieveComment {
-	api.arguments["context"] = "view"
-	return api
-}
-
-func (api *RetrieveComment) ContextEdit() *RetrieveComment {
-	api.arguments["context"] = "edit"
-	return api
-}
-
-func (api *RetrieveComment) ContextEmbed() *RetrieveComment {
-	api.arguments["context"] = "embed"
-	return api
-}
-
-func (api *RetrieveComment) Password(password string) *RetrieveComment {
-	api.arguments["password"] = password
-	return api
-}
-
-func (api *RetrieveComment) Do() (comment *Comment, err error) {
-	endpoint := api.client.endpoint + api.endpoint
-
-	restyClient := api.client.httpClient.R()
-	if api.client.auth.Username != "" && api.client.auth.Password != "" && api.arguments["context"] == "edit" {
-		restyClient.SetBasicAuth(api.client.auth.Username, api.client.auth.Password)
-	}
-
-	resp, err := restyClient.
-		SetHeader("Accept", "application/json").
-		SetResult(&comment).
-		SetQueryParams(api.arguments).
-		Get(endpoint)
-
-	if err != nil {
-		return
-	}
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-		if err != nil {
-			return
-		}
-		return comment, &wpError
-	}
-
-	return
-}
-
-type UpdateComment struct {
-	endpoint string
-	client   *RestClient
-	comment  CommentData
-}
-
-func (api *Comments) Update(comment CommentData) *UpdateComment {
-	return &UpdateComment{
-		endpoint: "/wp/v2/comments/" + strconv.Itoa(comment.ID),
-		client:   api.client,
-		comment:  comment,
-	}
-}
-
-func (api *UpdateComment) Do() (comment Comment, err error) {
-	resp, err := api.client.httpClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-		SetResult(&comment).
-		SetBody(api.comment).
-		Post(api.client.endpoint + api.endpoint)
-
-	if err != nil {
-		return
-	}
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-		if err != nil {
-			return
-		}
-		return comment, &wpError
-	}
-
-	return
-}
-
-type DeleteComment struct {
-	endpoint  string
-	client    *RestClient
-	commentID int
-	force     bool
-}
-
-func (api *Comments) Delete(commentID int) *DeleteComment {
-	return &DeleteComment{
-		endpoint:  "/wp/v2/comments",
-		client:    api.client,
-		commentID: commentID,
-	}
-}
-
-func (api *DeleteComment) Force() *DeleteComment {
-	api.force = true
-	return api
-}
-
-func (api *DeleteComment) Do() (deletedComment DeletedComment, err error) {
-	endpoint := api.client.endpoint + api.endpoint + "/" + strconv.Itoa(api.commentID)
-	resp, err := api.client.httpClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-		SetResult(&deletedComment).
-		SetQueryParam("force", strconv.FormatBool(api.force)).
-		Delete(endpoint)
-
-	if err != nil {
-		return
-	}
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-		if err != nil {
-			return
-		}
-		return deletedComment, &wpError
-	}
-
-	return
-}
+package gowprest
+
+import (
+	"encoding/json"
+	"maps"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Comment struct {
+	ID               int               `json:"id,omitempty"`
+	Post             int               `json:"post,omitempty"`
+	Parent           int               `json:"parent,omitempty"`
+	Author           int               `json:"author,omitempty"`
+	AuthorName       string            `json:"author_name,omitempty"`
+	AuthorEmail      string            `json:"author_email,omitempty"`
+	AuthorURL        string            `json:"author_url,omitempty"`
+	AuthorIP         string            `json:"author_ip,omitempty"`
+	AuthorUserAgent  string            `json:"author_user_agent,omitempty"`
+	Date             *Date             `json:"date,omitempty"`
+	DateGMT          *Date             `json:"date_gmt,omitempty"`
+	Content          *Object           `json:"content,omitempty"`
+	Link             string            `json:"link,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	Type             string            `json:"type,omitempty"`
+	AuthorAvatarURLs map[string]string `json:"author_avatar_urls,omitempty"`
+	Meta             map[string]any    `json:"meta,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type CommentData struct {
+	ID              int            `json:"id,omitempty"`
+	Post            int            `json:"post,omitempty"`
+	Parent          int            `json:"parent,omitempty"`
+	Author          int            `json:"author,omitempty"`
+	AuthorName      string         `json:"author_name,omitempty"`
+	AuthorEmail     string         `json:"author_email,omitempty"`
+	AuthorURL       string         `json:"author_url,omitempty"`
+	AuthorIP        string         `json:"author_ip,omitempty"`
+	AuthorUserAgent string         `json:"author_user_agent,omitempty"`
+	Date            *Date          `json:"date,omitempty"`
+	DateGMT         *Date          `json:"date_gmt,omitempty"`
+	Content         string         `json:"content,omitempty"`
+	Status          string         `json:"status,omitempty"`
+	Meta            map[string]any `json:"meta,omitempty"`
+}
+
+// Data returns the comment as CommentData carrying the raw content and the
+// author details only sent in the edit context.
+func (c Comment) Data() CommentData {
+	return CommentData{
+		ID:              c.ID,
+		Post:            c.Post,
+		Parent:          c.Parent,
+		Author:          c.Author,
+		AuthorName:      c.AuthorName,
+		AuthorEmail:     c.AuthorEmail,
+		AuthorURL:       c.AuthorURL,
+		AuthorIP:        c.AuthorIP,
+		AuthorUserAgent: c.AuthorUserAgent,
+		DateGMT:         c.DateGMT,
+		Content:         c.Content.raw(),
+		Status:          c.Status,
+		Meta:            c.Meta,
+	}
+}
+
+type DeletedComment struct {
+	Previous Comment `json:"previous"`
+	Deleted  bool    `json:"deleted"`
+}
+
+type Comments struct {
+	client *RestClient
+}
+
+func (c *RestClient) Comments() *Comments {
+	return &Comments{client: c}
+}
+
+type ListComments struct {
+	endpoint  string
+	client    *RestClient
+	arguments url.Values
+}
+
+func (api *ListComments) Clone() *ListComments {
+	clone := *api
+	clone.arguments = cloneValues(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListComments) With(options ...RequestOption) *ListComments {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Comments) List() *ListComments {
+	return &ListComments{
+		endpoint:  "/wp/v2/comments",
+		client:    api.client,
+		arguments: url.Values{},
+	}
+}
+
+func (api *ListComments) ContextView() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("context", "view")
+	return api
+}
+
+func (api *ListComments) ContextEdit() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("context", "edit")
+	return api
+}
+
+func (api *ListComments) ContextEmbed() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("context", "embed")
+	return api
+}
+
+func (api *ListComments) Page(page int) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("page", strconv.Itoa(page))
+	return api
+}
+
+func (api *ListComments) PerPage(perPage int) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("per_page", strconv.Itoa(perPage))
+	return api
+}
+
+func (api *ListComments) Search(query string) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("search", query)
+	return api
+}
+
+func (api *ListComments) After(after time.Time) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("after", after.Format(time.RFC3339))
+	return api
+}
+
+func (api *ListComments) Before(before time.Time) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("before", before.Format(time.RFC3339))
+	return api
+}
+
+func (api *ListComments) Author(authorIDs ...int) *ListComments {
+	api = api.Clone()
+	setIDs(api.arguments, "author", authorIDs...)
+	return api
+}
+
+func (api *ListComments) AuthorExclude(authorIDs ...int) *ListComments {
+	api = api.Clone()
+	setIDs(api.arguments, "author_exclude", authorIDs...)
+	return api
+}
+
+func (api *ListComments) AuthorEmail(email string) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("author_email", email)
+	return api
+}
+
+func (api *ListComments) Exclude(excludeIDs ...int) *ListComments {
+	api = api.Clone()
+	setIDs(api.arguments, "exclude", excludeIDs...)
+	return api
+}
+
+func (api *ListComments) Include(includeIDs ...int) *ListComments {
+	api = api.Clone()
+	setIDs(api.arguments, "include", includeIDs...)
+	return api
+}
+
+func (api *ListComments) Offset(offset int) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("offset", strconv.Itoa(offset))
+	return api
+}
+
+func (api *ListComments) OrderAsc() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("order", "asc")
+	return api
+}
+
+func (api *ListComments) OrderDesc() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("order", "desc")
+	return api
+}
+
+func (api *ListComments) OrderByDate() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("orderby", "date")
+	return api
+}
+
+func (api *ListComments) OrderByID() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("orderby", "id")
+	return api
+}
+
+func (api *ListComments) OrderByInclude() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("orderby", "include")
+	return api
+}
+
+func (api *ListComments) OrderByPost() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("orderby", "post")
+	return api
+}
+
+func (api *ListComments) OrderByParent() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("orderby", "parent")
+	return api
+}
+
+func (api *ListComments) OrderByCommentType() *ListComments {
+	api = api.Clone()
+	api.arguments.Set("orderby", "type")
+	return api
+}
+
+func (api *ListComments) Parent(parentIDs ...int) *ListComments {
+	api = api.Clone()
+	setIDs(api.arguments, "parent", parentIDs...)
+	return api
+}
+
+func (api *ListComments) ParentExclude(parentIDs ...int) *ListComments {
+	api = api.Clone()
+	setIDs(api.arguments, "parent_exclude", parentIDs...)
+	return api
+}
+
+func (api *ListComments) Post(postIDs ...int) *ListComments {
+	api = api.Clone()
+	setIDs(api.arguments, "post", postIDs...)
+	return api
+}
+
+func (api *ListComments) Status(status string) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("status", status)
+	return api
+}
+
+func (api *ListComments) Type(commentType string) *ListComments {
+	api = api.Clone()
+	api.arguments.Set("type", commentType)
+	return api
+}
+
+func (api *ListComments) Do() (comments []Comment, err error) {
+	restyClient := api.client.request()
+	if api.arguments.Get("context") == "edit" || api.arguments.Has("status") {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&comments).
+		SetQueryParamsFromValues(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return comments, &wpError
+	}
+
+	return
+}
+
+type CreateComment struct {
+	endpoint string
+	client   *RestClient
+	comment  CommentData
+}
+
+func (api *CreateComment) Clone() *CreateComment {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *CreateComment) With(options ...RequestOption) *CreateComment {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Comments) Create(comment CommentData) *CreateComment {
+	return &CreateComment{
+		endpoint: "/wp/v2/comments",
+		client:   api.client,
+		comment:  comment,
+	}
+}
+
+func (api *CreateComment) Do() (comment Comment, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&comment).
+		SetBody(api.comment).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return comment, &wpError
+	}
+
+	return
+}
+
+type RetrieveComment struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *RetrieveComment) Clone() *RetrieveComment {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveComment) With(options ...RequestOption) *RetrieveComment {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Comments) Retrieve(commentID int) *RetrieveComment {
+	return &RetrieveComment{
+		endpoint:  "/wp/v2/comments/" + strconv.Itoa(commentID),
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *RetrieveComment) ContextView() *RetrieveComment {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *RetrieveComment) ContextEdit() *RetrieveComment {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *RetrieveComment) ContextEmbed() *RetrieveComment {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *RetrieveComment) Password(password string) *RetrieveComment {
+	api = api.Clone()
+	api.arguments["password"] = password
+	return api
+}
+
+func (api *RetrieveComment) Do() (comment *Comment, err error) {
+	endpoint := api.client.url(api.endpoint)
+
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&comment).
+		SetQueryParams(api.arguments).
+		Get(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return comment, &wpError
+	}
+
+	return
+}
+
+type UpdateComment struct {
+	endpoint string
+	client   *RestClient
+	comment  CommentData
+}
+
+func (api *UpdateComment) Clone() *UpdateComment {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdateComment) With(options ...RequestOption) *UpdateComment {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Comments) Update(comment CommentData) *UpdateComment {
+	return &UpdateComment{
+		endpoint: "/wp/v2/comments/" + strconv.Itoa(comment.ID),
+		client:   api.client,
+		comment:  comment,
+	}
+}
+
+func (api *UpdateComment) Do() (comment Comment, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&comment).
+		SetBody(api.comment).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return comment, &wpError
+	}
+
+	return
+}
+
+// PatchComment updates only the fields set on it, so a field can be set to
+// its zero value, such as an empty author URL.
+type PatchComment struct {
+	endpoint string
+	client   *RestClient
+	fields   map[string]any
+}
+
+func (api *PatchComment) Clone() *PatchComment {
+	clone := *api
+	clone.fields = cloneFields(api.fields)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *PatchComment) With(options ...RequestOption) *PatchComment {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Comments) Patch(commentID int) *PatchComment {
+	return &PatchComment{
+		endpoint: "/wp/v2/comments/" + strconv.Itoa(commentID),
+		client:   api.client,
+		fields:   make(map[string]any),
+	}
+}
+
+// Set sends value for the field as is; a nil value is sent as null.
+func (api *PatchComment) Set(field string, value any) *PatchComment {
+	api = api.Clone()
+	api.fields[field] = value
+	return api
+}
+
+func (api *PatchComment) Post(postID int) *PatchComment {
+	return api.Set("post", postID)
+}
+
+// Parent sets the comment replied to; 0 makes it a top-level comment.
+func (api *PatchComment) Parent(parentID int) *PatchComment {
+	return api.Set("parent", parentID)
+}
+
+func (api *PatchComment) Author(authorID int) *PatchComment {
+	return api.Set("author", authorID)
+}
+
+func (api *PatchComment) AuthorName(name string) *PatchComment {
+	return api.Set("author_name", name)
+}
+
+func (api *PatchComment) AuthorEmail(email string) *PatchComment {
+	return api.Set("author_email", email)
+}
+
+func (api *PatchComment) AuthorURL(url string) *PatchComment {
+	return api.Set("author_url", url)
+}
+
+func (api *PatchComment) AuthorIP(ip string) *PatchComment {
+	return api.Set("author_ip", ip)
+}
+
+func (api *PatchComment) AuthorUserAgent(userAgent string) *PatchComment {
+	return api.Set("author_user_agent", userAgent)
+}
+
+func (api *PatchComment) Date(date *Date) *PatchComment {
+	return api.Set("date", date)
+}
+
+func (api *PatchComment) DateGMT(date *Date) *PatchComment {
+	return api.Set("date_gmt", date)
+}
+
+func (api *PatchComment) Content(content string) *PatchComment {
+	return api.Set("content", content)
+}
+
+func (api *PatchComment) Status(status string) *PatchComment {
+	return api.Set("status", status)
+}
+
+// Meta sets a single meta key; nil deletes it.
+func (api *PatchComment) Meta(key string, value any) *PatchComment {
+	api = api.Clone()
+	meta, _ := api.fields["meta"].(map[string]any)
+	if meta == nil {
+		meta = make(map[string]any)
+		api.fields["meta"] = meta
+	}
+	meta[key] = value
+	return api
+}
+
+func (api *PatchComment) Do() (comment Comment, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&comment).
+		SetBody(api.fields).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return comment, &wpError
+	}
+
+	return
+}
+
+type DeleteComment struct {
+	endpoint  string
+	client    *RestClient
+	commentID int
+	force     bool
+}
+
+func (api *DeleteComment) Clone() *DeleteComment {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeleteComment) With(options ...RequestOption) *DeleteComment {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Comments) Delete(commentID int) *DeleteComment {
+	return &DeleteComment{
+		endpoint:  "/wp/v2/comments",
+		client:    api.client,
+		commentID: commentID,
+	}
+}
+
+func (api *DeleteComment) Force() *DeleteComment {
+	api = api.Clone()
+	api.force = true
+	return api
+}
+
+func (api *DeleteComment) Do() (deletedComment DeletedComment, err error) {
+	endpoint := api.client.url(api.endpoint + "/" + strconv.Itoa(api.commentID))
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&deletedComment).
+		SetQueryParam("force", strconv.FormatBool(api.force)).
+		Delete(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return deletedComment, &wpError
+	}
+
+	return
+}
@@ -0,0 +1,164 @@
+package gowprest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MethodOverride selects how PUT, PATCH and DELETE requests are tunneled
+// through POST for hosts whose firewall rejects those methods.
+type MethodOverride int
+
+const (
+	// MethodOverrideNone sends every request with its own method.
+	MethodOverrideNone MethodOverride = iota
+	// MethodOverrideHeader sends a POST with the X-HTTP-Method-Override header.
+	MethodOverrideHeader
+	// MethodOverrideQuery sends a POST with the _method query argument, for
+	// hosts that also strip custom headers.
+	MethodOverrideQuery
+)
+
+// Compatibility holds workarounds for hosts that sit behind proxies or
+// firewalls which interfere with the REST API. Every option maps to a
+// mechanism WordPress supports natively, and all of them are applied at the
+// transport so every builder honours them.
+type Compatibility struct {
+	// MethodOverride tunnels PUT, PATCH and DELETE through POST.
+	MethodOverride MethodOverride
+	// NonceInQuery moves the X-WP-Nonce header of CookieAuthentication to the
+	// _wpnonce query argument.
+	NonceInQuery bool
+	// Envelope asks WordPress to wrap every response with _envelope, so the
+	// real status code and headers survive proxies that rewrite them. The
+	// envelope is unwrapped before the response reaches the builders.
+	Envelope bool
+}
+
+// WithCompatibility enables the given workarounds for every request made by
+// the client.
+func (api *RestClient) WithCompatibility(compat Compatibility) *RestClient {
+	derived := api.derive()
+	derived.compat = compat
+	return derived
+}
+
+type compatibilityKey struct{}
+
+// contentLengthKey carries the length of a streamed request body, which
+// net/http cannot tell from an arbitrary reader.
+type contentLengthKey struct{}
+
+// compatibilityTransport rewrites requests according to the Compatibility
+// carried in the request context and unwraps enveloped responses. Requests
+// carrying a cassette are handed to it instead of the next transport, and
+// streamed bodies of known length get their Content-Length.
+type compatibilityTransport struct {
+	next http.RoundTripper
+}
+
+func (t *compatibilityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if cassette, found := req.Context().Value(cassetteKey{}).(*cassetteTransport); found {
+		next = cassette
+	}
+
+	if length, found := req.Context().Value(contentLengthKey{}).(int64); found && req.ContentLength == 0 {
+		req = req.Clone(req.Context())
+		req.ContentLength = length
+	}
+
+	compat, _ := req.Context().Value(compatibilityKey{}).(Compatibility)
+	if compat == (Compatibility{}) {
+		return next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+
+	method := req.Header.Get("X-HTTP-Method-Override")
+	if method == "" && req.Method != http.MethodGet && req.Method != http.MethodPost && req.Method != http.MethodHead {
+		method = req.Method
+	}
+
+	if method != "" {
+		switch compat.MethodOverride {
+		case MethodOverrideHeader:
+			req.Method = http.MethodPost
+			req.Header.Set("X-HTTP-Method-Override", method)
+		case MethodOverrideQuery:
+			req.Method = http.MethodPost
+			req.Header.Del("X-HTTP-Method-Override")
+			query.Set("_method", method)
+		}
+	}
+
+	if nonce := req.Header.Get("X-WP-Nonce"); compat.NonceInQuery && nonce != "" {
+		req.Header.Del("X-WP-Nonce")
+		query.Set("_wpnonce", nonce)
+	}
+
+	if compat.Envelope {
+		query.Set("_envelope", "1")
+	}
+
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := next.RoundTrip(req)
+	if err != nil || !compat.Envelope {
+		return resp, err
+	}
+
+	return unwrapEnvelope(resp)
+}
+
+// unwrapEnvelope replaces the response status, headers and body with the
+// ones found in the _envelope body. Responses that are not an envelope, such
+// as an error page injected by a proxy, are passed through untouched.
+func unwrapEnvelope(resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope struct {
+		Body    json.RawMessage `json:"body"`
+		Status  int             `json:"status"`
+		Headers map[string]any  `json:"headers"`
+	}
+
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Status == 0 {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	for key, value := range envelope.Headers {
+		switch value := value.(type) {
+		case []any:
+			resp.Header.Del(key)
+			for _, v := range value {
+				resp.Header.Add(key, fmt.Sprint(v))
+			}
+		default:
+			resp.Header.Set(key, fmt.Sprint(value))
+		}
+	}
+
+	resp.StatusCode = envelope.Status
+	resp.Status = strconv.Itoa(envelope.Status) + " " + http.StatusText(envelope.Status)
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(envelope.Body))
+	resp.Body = io.NopCloser(bytes.NewReader(envelope.Body))
+
+	if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		resp.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,65 @@
+package gowprest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrConflict is matched by the ConflictError an update returns when the
+// resource was modified after the time given to IfUnmodifiedSince.
+var ErrConflict = errors.New("gowprest: resource was modified by someone else")
+
+// ConflictError holds both sides of a rejected update: Current is the
+// version stored on the site, Attempted is the data that was not written.
+type ConflictError[T any, D any] struct {
+	Expected  time.Time
+	Modified  time.Time
+	Current   T
+	Attempted D
+}
+
+func (e *ConflictError[T, D]) Error() string {
+	return fmt.Sprintf("%s: modified at %s, expected %s",
+		ErrConflict, e.Modified.Format(time.RFC3339), e.Expected.Format(time.RFC3339))
+}
+
+func (e *ConflictError[T, D]) Unwrap() error {
+	return ErrConflict
+}
+
+// ErrModifiedUnknown is returned by an update guarded with
+// IfUnmodifiedSince when the current version read back has no modified_gmt,
+// for example because a proxy or a _fields filter stripped it. The write is
+// not attempted, as it could not be checked.
+var ErrModifiedUnknown = errors.New("gowprest: cannot check for conflicts without the modified_gmt of the current version")
+
+// checkUnmodified returns a ConflictError when the modified_gmt of current,
+// read by modifiedGMT, is later than since. WordPress stores modification
+// times to the second, so since is compared at that precision.
+func checkUnmodified[T any, D any](current *T, modifiedGMT func(T) *Date, since time.Time, attempted D) error {
+	if current == nil {
+		return ErrModifiedUnknown
+	}
+
+	date := modifiedGMT(*current)
+	if date == nil || date.IsZero() {
+		return ErrModifiedUnknown
+	}
+
+	modified := date.Resolve(time.UTC)
+	if !modified.After(since.Truncate(time.Second)) {
+		return nil
+	}
+
+	return &ConflictError[T, D]{
+		Expected:  since,
+		Modified:  modified,
+		Current:   *current,
+		Attempted: attempted,
+	}
+}
+
+func modifiedGMTOfPost(post Post) *Date { return post.ModifiedGMT }
+
+func modifiedGMTOfPage(page Page) *Date { return page.ModifiedGMT }
@@ -0,0 +1,129 @@
+package gowprest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02T15:04:05"
+
+// Date is a date field of the REST API.
+//
+// WordPress sends the local fields, such as date and modified, as wall-clock
+// time in the site's time zone, and the _gmt fields as wall-clock time in
+// UTC, both without an offset. Such a date denotes no instant on its own, so
+// Date only exposes its time through Resolve, given the zone to read the
+// wall clock in. Null and empty values, sent for the date_gmt of drafts,
+// decode to the zero Date.
+//
+// When sent, a Date carrying a zone is written in RFC 3339 with its offset,
+// which WordPress converts to both the local and the GMT field. A Date
+// without one, as decoded or made by LocalDate, is written without an offset
+// and read by WordPress in the site's time zone, or in UTC for _gmt fields.
+//
+// Earlier versions embedded time.Time, which read local fields as UTC. Code
+// using post.Date.Time or methods such as post.Date.Format no longer
+// compiles: call Resolve instead, or Time while migrating.
+type Date struct {
+	t        time.Time
+	floating bool
+}
+
+// NewDate returns a Date for the instant t.
+func NewDate(t time.Time) *Date {
+	return &Date{t: t}
+}
+
+// LocalDate returns a Date holding the wall clock of t without its zone, for
+// a time given in the site's time zone.
+func LocalDate(t time.Time) *Date {
+	return &Date{
+		t:        time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC),
+		floating: true,
+	}
+}
+
+// IsZero reports whether the date is unset.
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// HasOffset reports whether the date carries a zone, as opposed to a wall
+// clock WordPress sent without an offset.
+func (d Date) HasOffset() bool {
+	return !d.floating
+}
+
+// Resolve returns the instant the date denotes. A date without an offset is
+// read as wall-clock time in loc: pass time.UTC for the _gmt fields and the
+// site's BlogInfo.Location for the local ones. A date with an offset is
+// returned as is.
+func (d Date) Resolve(loc *time.Location) time.Time {
+	if !d.floating || d.IsZero() {
+		return d.t
+	}
+	return time.Date(d.t.Year(), d.t.Month(), d.t.Day(), d.t.Hour(), d.t.Minute(), d.t.Second(), d.t.Nanosecond(), loc)
+}
+
+// Time returns the date as decoded, which is what the embedded time.Time of
+// earlier versions held: the instant for a date with an offset, and the wall
+// clock labelled UTC for one without, which is only the right instant for
+// _gmt fields.
+//
+// Deprecated: Use Resolve, which reads the wall clock in the right zone.
+func (d Date) Time() time.Time {
+	return d.t
+}
+
+// String returns the date as it is sent to WordPress.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	if d.floating {
+		return d.t.Format(dateLayout)
+	}
+	return d.t.Format(time.RFC3339)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts the formats of rest_parse_date: RFC 3339 with an
+// offset, or a date and time separated by "T" or a space without one.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if s == "" || strings.HasPrefix(s, "0000-00-00") {
+		*d = Date{}
+		return nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		*d = Date{t: t}
+		return nil
+	}
+
+	for _, layout := range []string{dateLayout, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			*d = Date{t: t, floating: true}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("gowprest: invalid date %q", s)
+}
@@ -0,0 +1,175 @@
+package gowprest
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Extras holds the members of a response that none of the model's fields
+// claim, such as fields added by plugins through register_rest_field, keyed
+// by name.
+type Extras map[string]json.RawMessage
+
+// Decode unmarshals the member name into v. It reports false when the
+// response had no such member.
+func (e Extras) Decode(name string, v any) (found bool, err error) {
+	data, found := e[name]
+	if !found {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// DecodeExtras unmarshals all extras into a T, typically a struct declaring
+// the plugin fields a caller needs:
+//
+//	type seo struct {
+//		YoastHead string `json:"yoast_head"`
+//	}
+//
+//	meta, err := gowprest.DecodeExtras[seo](post.Extras)
+func DecodeExtras[T any](extras Extras) (v T, err error) {
+	data, err := json.Marshal(extras)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &v)
+	return
+}
+
+var extrasType = reflect.TypeFor[Extras]()
+
+// decodeJSON is the JSON decoder of every client; see unmarshal.
+func decodeJSON(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return unmarshal(data, v)
+}
+
+// unmarshal decodes data into v like json.Unmarshal, then fills the Extras
+// field of every struct it decoded, including structs in slices and in
+// fields, with the members left unclaimed.
+func unmarshal(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	fillExtras(data, reflect.ValueOf(v))
+	return nil
+}
+
+func fillExtras(data []byte, v reflect.Value) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			fillExtras(items[i], v.Index(i))
+		}
+
+	case reflect.Map:
+		// Map elements cannot be set in place, so each is filled in a copy
+		// that replaces it.
+		if v.Type().Key().Kind() != reflect.String || v.IsNil() {
+			return
+		}
+		var members map[string]json.RawMessage
+		if json.Unmarshal(data, &members) != nil {
+			return
+		}
+		for key, member := range members {
+			k := reflect.ValueOf(key).Convert(v.Type().Key())
+			current := v.MapIndex(k)
+			if !current.IsValid() {
+				continue
+			}
+			element := reflect.New(v.Type().Elem()).Elem()
+			element.Set(current)
+			fillExtras(member, element.Addr())
+			v.SetMapIndex(k, element)
+		}
+
+	case reflect.Struct:
+		var members map[string]json.RawMessage
+		if json.Unmarshal(data, &members) != nil {
+			return
+		}
+
+		fields := jsonFields(v.Type())
+		extras := Extras{}
+		for name, member := range members {
+			index, claimed := fields.byName[strings.ToLower(name)]
+			if !claimed {
+				extras[name] = member
+				continue
+			}
+			if field, err := v.FieldByIndexErr(index); err == nil {
+				fillExtras(member, field)
+			}
+		}
+
+		if fields.extras != nil && len(extras) > 0 {
+			if field, err := v.FieldByIndexErr(fields.extras); err == nil && field.CanSet() {
+				field.Set(reflect.ValueOf(extras))
+			}
+		}
+	}
+}
+
+type structFields struct {
+	byName map[string][]int
+	extras []int
+}
+
+var structFieldsCache sync.Map
+
+// jsonFields maps the lowercased JSON names of the fields of t, promoted
+// ones included, to their index, matching the case-insensitive lookup of
+// encoding/json.
+func jsonFields(t reflect.Type) *structFields {
+	if cached, found := structFieldsCache.Load(t); found {
+		return cached.(*structFields)
+	}
+
+	fields := &structFields{byName: make(map[string][]int)}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+		if field.Type == extrasType {
+			fields.extras = field.Index
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, taken := fields.byName[strings.ToLower(name)]; !taken || len(field.Index) == 1 {
+			fields.byName[strings.ToLower(name)] = field.Index
+		}
+	}
+
+	structFieldsCache.Store(t, fields)
+	return fields
+}
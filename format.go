@@ -1,56 +1,49 @@
-package gowprest
-
-import (
-	"encoding/json"
-	"fmt"
-)
-
-type Format string
-
-const (
-	FormatStandard Format = "standard"
-	FormatAside    Format = "aside"
-	FormatAudio    Format = "audio"
-	FormatChat     Format = "chat"
-	FormatGallery  Format = "gallery"
-	FormatImage    Format = "image"
-	FormatLink     Format = "link"
-	FormatQuote    Format = "quote"
-	FormatStatus   Format = "status"
-	FormatVideo    Format = "video"
-)
-
-func (s Format) MarshalJSON() ([]byte, error) {
-	return json.Marshal(string(s))
-}
-func (s *Format) UnmarshalJSON(data []byte) error {
-	var format string
-	if err := json.Unmarshal(data, &format); err != nil {
-		return err
-	}
-	switch format {
-	case string(FormatStandard):
-		*s = FormatStandard
-	case string(FormatAside):
-		*s = FormatAside
-	case string(FormatAudio):
-		*s = FormatAudio
-	case string(FormatChat):
-		*s = FormatChat
-	case string(FormatGallery):
-		*s = FormatGallery
-	case string(FormatImage):
-		*s = FormatImage
-	case string(FormatLink):
-		*s = FormatLink
-	case string(FormatQuote):
-		*s = FormatQuote
-	case string(FormatStatus):
-		*s = FormatStatus
-	case string(FormatVideo):
-		*s = FormatVideo
-	default:
-		return fmt.Errorf("invalid format: %s", format)
-	}
-	return nil
-}
+package gowprest
+
+import (
+	"encoding/json"
+)
+
+type Format string
+
+const (
+	FormatStandard Format = "standard"
+	FormatAside    Format = "aside"
+	FormatAudio    Format = "audio"
+	FormatChat     Format = "chat"
+	FormatGallery  Format = "gallery"
+	FormatImage    Format = "image"
+	FormatLink     Format = "link"
+	FormatQuote    Format = "quote"
+	FormatStatus   Format = "status"
+	FormatVideo    Format = "video"
+)
+
+func (s Format) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+// IsKnown reports whether s is one of the post formats supported by core.
+func (s Format) IsKnown() bool {
+	switch s {
+	case FormatStandard, FormatAside, FormatAudio, FormatChat, FormatGallery,
+		FormatImage, FormatLink, FormatQuote, FormatStatus, FormatVideo:
+		return true
+	}
+	return false
+}
+
+// UnmarshalJSON accepts any format, so a post decodes even when a theme
+// registers its own; use IsKnown to check it.
+func (s *Format) UnmarshalJSON(data []byte) error {
+	var format *string
+	if err := json.Unmarshal(data, &format); err != nil {
+		return err
+	}
+
+	*s = ""
+	if format != nil {
+		*s = Format(*format)
+	}
+	return nil
+}
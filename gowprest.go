@@ -1,68 +1,251 @@
-package gowprest
-
-import (
-	"strings"
-
-	"resty.dev/v3"
-)
-
-type BlogInfo struct {
-	Name           string   `json:"name"`
-	Description    string   `json:"description"`
-	URL            string   `json:"url"`
-	Home           string   `json:"home"`
-	GmtOffset      string   `json:"gmt_offset"`
-	TimezoneString string   `json:"timezone_string"`
-	PageForPosts   int      `json:"page_for_posts"`
-	PageOnFront    int      `json:"page_on_front"`
-	ShowOnFront    string   `json:"show_on_front"`
-	Namespaces     []string `json:"namespaces"`
-	Authentication any      `json:"authentication"`
-	SiteLogo       int      `json:"site_logo"`
-	SiteIcon       int      `json:"site_icon"`
-	SiteIconURL    string   `json:"site_icon_url"`
-}
-
-type Authentication struct {
-	Username string
-	Password string
-}
-
-type RestClient struct {
-	baseURL  string
-	endpoint string
-	auth     Authentication
-
-	httpClient *resty.Client
-}
-
-func (api *RestClient) Close() {
-	api.httpClient.Close()
-}
-
-func (api *RestClient) WithBasicAuth(username, password string) *RestClient {
-	api.auth.Username = username
-	api.auth.Password = password
-	return api
-}
-
-func (api *RestClient) Discover() (info BlogInfo, err error) {
-	_, err = api.httpClient.R().
-		SetHeader("Accept", "application/json").
-		SetResult(&info).
-		Get(api.endpoint)
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-func NewClient(baseURL string) *RestClient {
-	client := resty.New()
-	return &RestClient{
-		baseURL:    baseURL,
-		endpoint:   strings.Trim(baseURL, "/") + "/wp-json",
-		httpClient: client}
-}
+package gowprest
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"resty.dev/v3"
+)
+
+type BlogInfo struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	URL            string   `json:"url"`
+	Home           string   `json:"home"`
+	GmtOffset      string   `json:"gmt_offset"`
+	TimezoneString string   `json:"timezone_string"`
+	PageForPosts   int      `json:"page_for_posts"`
+	PageOnFront    int      `json:"page_on_front"`
+	ShowOnFront    string   `json:"show_on_front"`
+	Namespaces     []string `json:"namespaces"`
+	Authentication any      `json:"authentication"`
+	SiteLogo       int      `json:"site_logo"`
+	SiteIcon       int      `json:"site_icon"`
+	SiteIconURL    string   `json:"site_icon_url"`
+
+	Extras Extras `json:"-"`
+}
+
+// Location returns the site's time zone: the zone named by timezone_string
+// when it is known to the tz database, otherwise a fixed zone gmt_offset
+// hours east of UTC.
+func (info BlogInfo) Location() *time.Location {
+	if info.TimezoneString != "" {
+		if loc, err := time.LoadLocation(info.TimezoneString); err == nil {
+			return loc
+		}
+	}
+
+	offset, _ := strconv.ParseFloat(info.GmtOffset, 64)
+	if offset == 0 {
+		return time.UTC
+	}
+
+	return time.FixedZone("", int(offset*3600))
+}
+
+type Authentication struct {
+	Username string
+	Password string
+}
+
+// Authenticate sends the username and application password with HTTP Basic auth.
+func (a Authentication) Authenticate(req *resty.Request) {
+	if a.Username == "" || a.Password == "" {
+		return
+	}
+	req.SetBasicAuth(a.Username, a.Password)
+}
+
+// CookieAuthentication authenticates with a logged-in user's cookies and the
+// wp_rest nonce, the mechanism WordPress uses for its own admin screens. It
+// keeps working on hosts that strip the Authorization header.
+type CookieAuthentication struct {
+	Nonce   string
+	Cookies []*http.Cookie
+}
+
+// Authenticate sends the cookies and the nonce in the X-WP-Nonce header, or
+// as the _wpnonce query argument when Compatibility.NonceInQuery is set.
+func (a CookieAuthentication) Authenticate(req *resty.Request) {
+	req.SetCookies(a.Cookies)
+	req.SetHeader("X-WP-Nonce", a.Nonce)
+}
+
+// TokenAuthentication sends an OAuth2 bearer token, as issued by WordPress.com
+// or by OAuth plugins on self-hosted sites.
+type TokenAuthentication struct {
+	Token string
+}
+
+func (a TokenAuthentication) Authenticate(req *resty.Request) {
+	if a.Token == "" {
+		return
+	}
+	req.SetAuthToken(a.Token)
+}
+
+// Authenticator attaches credentials to an outgoing request.
+type Authenticator interface {
+	Authenticate(req *resty.Request)
+}
+
+// RestClient is a client for one site. The With methods never change the
+// client they are called on: each returns a derived client that shares the
+// underlying HTTP client and its connections, so one base client can serve
+// many users or tenants concurrently.
+type RestClient struct {
+	baseURL  string
+	endpoint string
+	site     string
+	auth     Authenticator
+	compat   Compatibility
+	cassette *cassetteTransport
+
+	ctx       context.Context
+	headers   http.Header
+	timeout   time.Duration
+	locale    string
+	forceAuth bool
+
+	httpClient *resty.Client
+}
+
+// Close releases the HTTP client, which is shared by every client derived
+// from the same NewClient call.
+func (api *RestClient) Close() {
+	api.httpClient.Close()
+}
+
+// derive returns a copy of the client sharing its HTTP client.
+func (api *RestClient) derive() *RestClient {
+	derived := *api
+	return &derived
+}
+
+func (api *RestClient) WithBasicAuth(username, password string) *RestClient {
+	derived := api.derive()
+	derived.auth = Authentication{Username: username, Password: password}
+	return derived
+}
+
+func (api *RestClient) WithBearerToken(token string) *RestClient {
+	derived := api.derive()
+	derived.auth = TokenAuthentication{Token: token}
+	return derived
+}
+
+func (api *RestClient) WithAuthenticator(auth Authenticator) *RestClient {
+	derived := api.derive()
+	derived.auth = auth
+	return derived
+}
+
+// url resolves an API route such as /wp/v2/posts against the client's endpoint.
+// On WordPress.com the site is inserted after the namespace, so /wp/v2/posts
+// becomes /wp/v2/sites/{site}/posts.
+func (api *RestClient) url(route string) string {
+	if api.site == "" {
+		return api.endpoint + route
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(route, "/"), "/", 3)
+	if len(parts) < 2 {
+		return api.endpoint + "/wp/v2/sites/" + api.site
+	}
+
+	resolved := api.endpoint + "/" + parts[0] + "/" + parts[1] + "/sites/" + api.site
+	if len(parts) == 3 {
+		resolved += "/" + parts[2]
+	}
+
+	return resolved
+}
+
+// context returns the context requests are bound to, as set by the Context
+// option.
+func (api *RestClient) context() context.Context {
+	if api.ctx == nil {
+		return context.Background()
+	}
+	return api.ctx
+}
+
+// request starts a request that carries the client's compatibility settings
+// and cassette down to the transport, with its request options applied.
+func (api *RestClient) request() *resty.Request {
+	ctx := context.WithValue(api.context(), compatibilityKey{}, api.compat)
+	if api.cassette != nil {
+		ctx = context.WithValue(ctx, cassetteKey{}, api.cassette)
+	}
+
+	req := api.httpClient.R().
+		SetContext(ctx).
+		SetHeaderMultiValues(api.headers)
+
+	if api.timeout > 0 {
+		req.SetTimeout(api.timeout)
+	}
+	if api.locale != "" {
+		req.SetQueryParam("_locale", api.locale)
+	}
+	if api.forceAuth && api.auth != nil {
+		api.auth.Authenticate(req)
+	}
+
+	return req
+}
+
+// authenticate attaches the client's credentials to the request, if any.
+// With the Auth option request has attached them already, and attaching
+// them again would send cookies twice.
+func (api *RestClient) authenticate(req *resty.Request) *resty.Request {
+	if api.auth != nil && !api.forceAuth {
+		api.auth.Authenticate(req)
+	}
+	return req
+}
+
+func (api *RestClient) Discover() (info BlogInfo, err error) {
+	_, err = api.request().
+		SetHeader("Accept", "application/json").
+		SetResult(&info).
+		Get(api.url(""))
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// wpcomSitePath matches the WordPress.com route layout, where the wp/v2 routes
+// of a site live under https://public-api.wordpress.com/wp/v2/sites/{site}.
+var wpcomSitePath = regexp.MustCompile(`^(.*?)/wp/v2/sites/([^/]+)$`)
+
+// NewClient returns a client for the site at baseURL. Self-hosted sites are
+// given by their home URL and reached through /wp-json; WordPress.com sites
+// are given by their public-api.wordpress.com/wp/v2/sites/{site} URL and are
+// usually combined with WithBearerToken.
+func NewClient(baseURL string) *RestClient {
+	client := resty.New()
+	client.SetTransport(&compatibilityTransport{next: client.Transport()})
+	client.AddContentTypeDecoder("json", decodeJSON)
+
+	endpoint := strings.Trim(baseURL, "/") + "/wp-json"
+	site := ""
+	if match := wpcomSitePath.FindStringSubmatch(strings.TrimRight(baseURL, "/")); match != nil {
+		endpoint = match[1]
+		site = match[2]
+	}
+
+	return &RestClient{
+		baseURL:    baseURL,
+		endpoint:   endpoint,
+		site:       site,
+		httpClient: client}
+}
@@ -0,0 +1,227 @@
+package gowprest
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+)
+
+// ImageProcessing prepares JPEG and PNG images before they are uploaded:
+// they are decoded, turned upright according to their EXIF orientation,
+// scaled down and encoded again, which drops every metadata block such as
+// EXIF location data. Other files are uploaded unchanged.
+type ImageProcessing struct {
+	// MaxDimension scales images down so that neither side exceeds it,
+	// keeping the aspect ratio. Zero keeps the size.
+	MaxDimension int
+	// Quality is the JPEG quality from 1 to 100. Zero uses 82, the quality
+	// WordPress itself encodes with. PNG images are lossless and always
+	// encoded with the best compression.
+	Quality int
+}
+
+// process returns the processed image read from reader, or its bytes
+// unchanged when contentType is not a JPEG or PNG image.
+func (p ImageProcessing) process(reader io.Reader, contentType string) ([]byte, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return data, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	img := toNRGBA(decoded)
+	if bounds := img.Bounds(); p.MaxDimension > 0 && max(bounds.Dx(), bounds.Dy()) > p.MaxDimension {
+		scale := float64(p.MaxDimension) / float64(max(bounds.Dx(), bounds.Dy()))
+		img = downscale(img,
+			max(1, int(math.Round(float64(bounds.Dx())*scale))),
+			max(1, int(math.Round(float64(bounds.Dy())*scale))))
+	}
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(data))
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: cmp.Or(p.Quality, 82)})
+	} else {
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	if img, isNRGBA := src.(*image.NRGBA); isNRGBA && img.Rect.Min == (image.Point{}) {
+		return img
+	}
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// downscale resizes src to width by height by averaging the source pixels
+// each destination pixel covers, weighted by their alpha.
+func downscale(src *image.NRGBA, width, height int) *image.NRGBA {
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		y0 := y * srcHeight / height
+		y1 := max(y0+1, (y+1)*srcHeight/height)
+		for x := range width {
+			x0 := x * srcWidth / width
+			x1 := max(x0+1, (x+1)*srcWidth/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					pixel := row[sx*4 : sx*4+4]
+					alpha := uint64(pixel[3])
+					r += uint64(pixel[0]) * alpha
+					g += uint64(pixel[1]) * alpha
+					b += uint64(pixel[2]) * alpha
+					a += alpha
+					n++
+				}
+			}
+
+			offset := y*dst.Stride + x*4
+			if a > 0 {
+				dst.Pix[offset] = uint8((r + a/2) / a)
+				dst.Pix[offset+1] = uint8((g + a/2) / a)
+				dst.Pix[offset+2] = uint8((b + a/2) / a)
+			}
+			dst.Pix[offset+3] = uint8((a + n/2) / n)
+		}
+	}
+
+	return dst
+}
+
+// orient turns src upright according to an EXIF orientation from 1 to 8.
+func orient(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := range dstHeight {
+		for x := range dstWidth {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = width-1-x, y
+			case 3:
+				sx, sy = width-1-x, height-1-y
+			case 4:
+				sx, sy = x, height-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, height-1-x
+			case 7:
+				sx, sy = width-1-y, height-1-x
+			case 8:
+				sx, sy = width-1-y, x
+			}
+			copy(dst.Pix[y*dst.Stride+x*4:][:4], src.Pix[sy*src.Stride+sx*4:][:4])
+		}
+	}
+
+	return dst
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG file, or 1 when it
+// has none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA {
+			// Image data starts; metadata only comes before it.
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// exifOrientation reads the Orientation tag from the first IFD of a TIFF
+// structure, as embedded in a JPEG APP1 segment.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for i := range count {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+
+	return 1
+}
@@ -1,32 +1,36 @@
-package gowprest
-
-import (
-	"encoding/json"
-	"fmt"
-)
-
-type OpenClosedStatus string
-
-const (
-	StatusOpen   OpenClosedStatus = "open"
-	StatusClosed OpenClosedStatus = "closed"
-)
-
-func (s OpenClosedStatus) MarshalJSON() ([]byte, error) {
-	return json.Marshal(string(s))
-}
-
-func (s *OpenClosedStatus) UnmarshalJSON(data []byte) error {
-	var ocStatus string
-	if err := json.Unmarshal(data, &ocStatus); err != nil {
-		return err
-	}
-
-	switch ocStatus {
-	case string(StatusOpen), string(StatusClosed):
-		*s = OpenClosedStatus(ocStatus)
-		return nil
-	default:
-		return fmt.Errorf("invalid suit value: %s", ocStatus)
-	}
-}
+package gowprest
+
+import (
+	"encoding/json"
+)
+
+type OpenClosedStatus string
+
+const (
+	StatusOpen   OpenClosedStatus = "open"
+	StatusClosed OpenClosedStatus = "closed"
+)
+
+// IsKnown reports whether s is open or closed.
+func (s OpenClosedStatus) IsKnown() bool {
+	return s == StatusOpen || s == StatusClosed
+}
+
+func (s OpenClosedStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON accepts any value, so a post decodes even when a plugin
+// reports another status; use IsKnown to check it.
+func (s *OpenClosedStatus) UnmarshalJSON(data []byte) error {
+	var ocStatus *string
+	if err := json.Unmarshal(data, &ocStatus); err != nil {
+		return err
+	}
+
+	*s = ""
+	if ocStatus != nil {
+		*s = OpenClosedStatus(*ocStatus)
+	}
+	return nil
+}
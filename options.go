@@ -0,0 +1,72 @@
+package gowprest
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption overrides a setting of the client for the requests of one
+// builder, or of a derived client when passed to RestClient.With.
+type RequestOption func(client *RestClient)
+
+// Header sets a header of the request, replacing the value given by the
+// client or an earlier option.
+func Header(key, value string) RequestOption {
+	return func(client *RestClient) {
+		client.headers = client.headers.Clone()
+		if client.headers == nil {
+			client.headers = http.Header{}
+		}
+		client.headers.Set(key, value)
+	}
+}
+
+// Auth sends the request with auth instead of the client's credentials. The
+// request is authenticated even by builders that otherwise send public
+// requests anonymously.
+func Auth(auth Authenticator) RequestOption {
+	return func(client *RestClient) {
+		client.auth = auth
+		client.forceAuth = true
+	}
+}
+
+// BasicAuth sends the request with the given application password.
+func BasicAuth(username, password string) RequestOption {
+	return Auth(Authentication{Username: username, Password: password})
+}
+
+// Context binds the request to ctx, so cancelling ctx or reaching its
+// deadline aborts it. Builders that send several requests, such as media
+// edits, sideloads and password rotation, stop at whichever is running.
+func Context(ctx context.Context) RequestOption {
+	return func(client *RestClient) {
+		client.ctx = ctx
+	}
+}
+
+// Timeout bounds the whole request, including reading the response.
+func Timeout(timeout time.Duration) RequestOption {
+	return func(client *RestClient) {
+		client.timeout = timeout
+	}
+}
+
+// Locale sets the _locale argument, which WordPress uses to translate
+// labels and messages: "user" selects the locale of the authenticated user,
+// anything else a locale such as "de_DE".
+func Locale(locale string) RequestOption {
+	return func(client *RestClient) {
+		client.locale = locale
+	}
+}
+
+// With returns a client derived with the given options.
+func (api *RestClient) With(options ...RequestOption) *RestClient {
+	derived := api.derive()
+	for _, option := range options {
+		option(derived)
+	}
+	return derived
+}
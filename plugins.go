@@ -1 +1,380 @@
-package gowprest
+package gowprest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrFilesystemCredentialsRequired is returned when the site cannot write
+// to its plugin directory without FTP or SSH credentials, which the REST
+// API has no way to ask for. Installing and deleting plugins fail on such
+// sites; activating and deactivating still work.
+var ErrFilesystemCredentialsRequired = errors.New("gowprest: the site needs filesystem credentials to manage plugin files")
+
+type PluginStatus string
+
+const (
+	PluginActive        PluginStatus = "active"
+	PluginInactive      PluginStatus = "inactive"
+	PluginNetworkActive PluginStatus = "network-active"
+)
+
+// IsKnown reports whether s is active, inactive or network-active.
+func (s PluginStatus) IsKnown() bool {
+	switch s {
+	case PluginActive, PluginInactive, PluginNetworkActive:
+		return true
+	}
+	return false
+}
+
+// Plugin is an installed plugin. Plugin identifies it by its main file
+// relative to the plugins directory, without the .php extension, such as
+// "akismet/akismet" or "hello".
+type Plugin struct {
+	Plugin      string       `json:"plugin,omitempty"`
+	Status      PluginStatus `json:"status,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	PluginURI   string       `json:"plugin_uri,omitempty"`
+	Author      string       `json:"author,omitempty"`
+	AuthorURI   string       `json:"author_uri,omitempty"`
+	Description *Object      `json:"description,omitempty"`
+	Version     string       `json:"version,omitempty"`
+	NetworkOnly bool         `json:"network_only,omitempty"`
+	RequiresWP  string       `json:"requires_wp,omitempty"`
+	RequiresPHP string       `json:"requires_php,omitempty"`
+	Textdomain  string       `json:"textdomain,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// IsActive reports whether the plugin runs on the site, either activated
+// there or across the network.
+func (p Plugin) IsActive() bool {
+	return p.Status == PluginActive || p.Status == PluginNetworkActive
+}
+
+// Plugins manages the installed plugins. Every request is authenticated.
+type Plugins struct {
+	client *RestClient
+}
+
+func (c *RestClient) Plugins() *Plugins {
+	return &Plugins{client: c}
+}
+
+// pluginEndpoint accepts the plugin file with or without its .php
+// extension, which the route does not allow.
+func pluginEndpoint(plugin string) string {
+	plugin = strings.TrimSuffix(plugin, ".php")
+	parts := strings.Split(plugin, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return "/wp/v2/plugins/" + strings.Join(parts, "/")
+}
+
+// pluginError converts an error response, marking the ones caused by a
+// filesystem that needs credentials: deleting checks for it up front and
+// fails with fs_unavailable, installing finds out while unpacking and fails
+// with unable_to_connect_to_filesystem.
+func pluginError(body []byte) error {
+	var wpError WPRestError
+	err := json.Unmarshal(body, &wpError)
+	if err != nil {
+		return err
+	}
+	switch wpError.Code {
+	case "fs_unavailable", "unable_to_connect_to_filesystem":
+		return errors.Join(ErrFilesystemCredentialsRequired, &wpError)
+	}
+	return &wpError
+}
+
+type ListPlugins struct {
+	endpoint  string
+	client    *RestClient
+	arguments url.Values
+}
+
+func (api *ListPlugins) Clone() *ListPlugins {
+	clone := *api
+	clone.arguments = cloneValues(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListPlugins) With(options ...RequestOption) *ListPlugins {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Plugins) List() *ListPlugins {
+	return &ListPlugins{
+		endpoint:  "/wp/v2/plugins",
+		client:    api.client,
+		arguments: url.Values{},
+	}
+}
+
+// StatusIn limits the result to plugins with any of the given statuses.
+func (api *ListPlugins) StatusIn(statuses ...PluginStatus) *ListPlugins {
+	api = api.Clone()
+	values := []string{}
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+	setList(api.arguments, "status", values...)
+	return api
+}
+
+func (api *ListPlugins) Search(query string) *ListPlugins {
+	api = api.Clone()
+	api.arguments.Set("search", query)
+	return api
+}
+
+func (api *ListPlugins) Do() (plugins []Plugin, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&plugins).
+		SetQueryParamsFromValues(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		return plugins, pluginError(resp.Bytes())
+	}
+
+	return
+}
+
+type RetrievePlugin struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *RetrievePlugin) Clone() *RetrievePlugin {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrievePlugin) With(options ...RequestOption) *RetrievePlugin {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Plugins) Retrieve(plugin string) *RetrievePlugin {
+	return &RetrievePlugin{
+		endpoint: pluginEndpoint(plugin),
+		client:   api.client,
+	}
+}
+
+func (api *RetrievePlugin) Do() (plugin *Plugin, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&plugin).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		return plugin, pluginError(resp.Bytes())
+	}
+
+	return
+}
+
+// InstallPlugin downloads a plugin from the WordPress.org directory.
+type InstallPlugin struct {
+	endpoint string
+	client   *RestClient
+	slug     string
+	status   PluginStatus
+}
+
+func (api *InstallPlugin) Clone() *InstallPlugin {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *InstallPlugin) With(options ...RequestOption) *InstallPlugin {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Install installs the plugin with the given directory slug, such as
+// "classic-editor". It is left inactive unless Status says otherwise.
+func (api *Plugins) Install(slug string) *InstallPlugin {
+	return &InstallPlugin{
+		endpoint: "/wp/v2/plugins",
+		client:   api.client,
+		slug:     slug,
+	}
+}
+
+// Status activates the plugin once installed; PluginNetworkActive is only
+// accepted on multisite networks.
+func (api *InstallPlugin) Status(status PluginStatus) *InstallPlugin {
+	api = api.Clone()
+	api.status = status
+	return api
+}
+
+func (api *InstallPlugin) Do() (plugin Plugin, err error) {
+	body := map[string]any{"slug": api.slug}
+	if api.status != "" {
+		body["status"] = api.status
+	}
+
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&plugin).
+		SetBody(body).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		return plugin, pluginError(resp.Bytes())
+	}
+
+	return
+}
+
+// UpdatePlugin changes the status of an installed plugin, the only field
+// the API lets clients write.
+type UpdatePlugin struct {
+	endpoint string
+	client   *RestClient
+	status   PluginStatus
+}
+
+func (api *UpdatePlugin) Clone() *UpdatePlugin {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdatePlugin) With(options ...RequestOption) *UpdatePlugin {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Plugins) Update(plugin string) *UpdatePlugin {
+	return &UpdatePlugin{
+		endpoint: pluginEndpoint(plugin),
+		client:   api.client,
+	}
+}
+
+// Activate activates the plugin on the site.
+func (api *Plugins) Activate(plugin string) *UpdatePlugin {
+	return api.Update(plugin).Status(PluginActive)
+}
+
+// Deactivate deactivates the plugin, on the whole network if it was
+// network-active.
+func (api *Plugins) Deactivate(plugin string) *UpdatePlugin {
+	return api.Update(plugin).Status(PluginInactive)
+}
+
+func (api *UpdatePlugin) Status(status PluginStatus) *UpdatePlugin {
+	api = api.Clone()
+	api.status = status
+	return api
+}
+
+// NetworkWide activates the plugin for every site of a multisite network.
+func (api *UpdatePlugin) NetworkWide() *UpdatePlugin {
+	return api.Status(PluginNetworkActive)
+}
+
+func (api *UpdatePlugin) Do() (plugin Plugin, err error) {
+	body := map[string]any{}
+	if api.status != "" {
+		body["status"] = api.status
+	}
+
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&plugin).
+		SetBody(body).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		return plugin, pluginError(resp.Bytes())
+	}
+
+	return
+}
+
+type DeletePlugin struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *DeletePlugin) Clone() *DeletePlugin {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeletePlugin) With(options ...RequestOption) *DeletePlugin {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Delete removes the plugin's files. WordPress refuses to delete an active
+// plugin, so deactivate it first.
+func (api *Plugins) Delete(plugin string) *DeletePlugin {
+	return &DeletePlugin{
+		endpoint: pluginEndpoint(plugin),
+		client:   api.client,
+	}
+}
+
+func (api *DeletePlugin) Do() (plugin Plugin, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		Delete(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		return plugin, pluginError(resp.Bytes())
+	}
+
+	var nested struct {
+		Deleted  bool   `json:"deleted"`
+		Previous Plugin `json:"previous"`
+	}
+	err = unmarshal(resp.Bytes(), &nested)
+	if err != nil {
+		return
+	}
+	return nested.Previous, nil
+}
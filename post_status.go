@@ -1,34 +1,48 @@
-package gowprest
-
-import (
-	"encoding/json"
-	"fmt"
-)
-
-type PostStatus string
-
-const (
-	StatusDraft     PostStatus = "draft"
-	StatusPending   PostStatus = "pending"
-	StatusPrivate   PostStatus = "private"
-	StatusPublished PostStatus = "publish"
-)
-
-func (s PostStatus) MarshalJSON() ([]byte, error) {
-	return json.Marshal(string(s))
-}
-
-func (s *PostStatus) UnmarshalJSON(data []byte) error {
-	var postStatus string
-	if err := json.Unmarshal(data, &postStatus); err != nil {
-		return err
-	}
-
-	switch postStatus {
-	case string(StatusDraft), string(StatusPending), string(StatusPrivate), string(StatusPublished):
-		*s = PostStatus(postStatus)
-		return nil
-	default:
-		return fmt.Errorf("invalid suit value: %s", postStatus)
-	}
-}
+package gowprest
+
+import (
+	"encoding/json"
+)
+
+type PostStatus string
+
+const (
+	StatusDraft     PostStatus = "draft"
+	StatusPending   PostStatus = "pending"
+	StatusPrivate   PostStatus = "private"
+	StatusPublished PostStatus = "publish"
+	StatusFuture    PostStatus = "future"
+	StatusTrash     PostStatus = "trash"
+	StatusAutoDraft PostStatus = "auto-draft"
+	StatusInherit   PostStatus = "inherit"
+)
+
+// IsKnown reports whether s is one of the statuses registered by WordPress
+// core, as opposed to a custom status registered by a plugin.
+func (s PostStatus) IsKnown() bool {
+	switch s {
+	case StatusDraft, StatusPending, StatusPrivate, StatusPublished,
+		StatusFuture, StatusTrash, StatusAutoDraft, StatusInherit:
+		return true
+	}
+	return false
+}
+
+func (s PostStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON accepts any status, so posts with a custom status still
+// decode; use IsKnown to tell them apart.
+func (s *PostStatus) UnmarshalJSON(data []byte) error {
+	var postStatus *string
+	if err := json.Unmarshal(data, &postStatus); err != nil {
+		return err
+	}
+
+	*s = ""
+	if postStatus != nil {
+		*s = PostStatus(*postStatus)
+	}
+	return nil
+}
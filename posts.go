@@ -1,557 +1,1022 @@
-package gowprest
-
-import (
-	"encoding/json"
-	"strconv"
-	"strings"
-	"time"
-)
-
-type Object struct {
-	Rendered  string `json:"rendered"`
-	Protected bool   `json:"protected,omitempty,omitzero"`
-}
-
-type Date struct {
-	time.Time
-}
-
-func (ct *Date) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
-	t, err := time.Parse("2006-01-02T15:04:05", s)
-	if err != nil {
-		return err
-	}
-	ct.Time = t
-	return nil
-}
-
-type Post struct {
-	Date              *Date            `json:"date,omitempty"`
-	DateGMT           *Date            `json:"date_gmt,omitempty"`
-	GUID              *Object          `json:"guid,omitempty"`
-	ID                int              `json:"id,omitempty"`
-	Link              string           `json:"link,omitempty"`
-	Modified          *Date            `json:"modified,omitempty"`
-	ModifiedGMT       *Date            `json:"modified_gmt,omitempty"`
-	Slug              string           `json:"slug,omitempty"`
-	Status            PostStatus       `json:"status,omitempty"`
-	Type              string           `json:"type,omitempty"`
-	Password          string           `json:"password,omitempty"`
-	PermalinkTemplate string           `json:"permalink_template,omitempty"`
-	GeneratedSlug     string           `json:"generated_slug,omitempty"`
-	Title             *Object          `json:"title,omitempty"`
-	Content           *Object          `json:"content,omitempty"`
-	Author            int              `json:"author,omitempty"`
-	Excerpt           *Object          `json:"excerpt,omitempty"`
-	FeaturedMedia     int              `json:"featured_media,omitempty"`
-	CommentStatus     OpenClosedStatus `json:"comment_status,omitempty"`
-	PingStatus        OpenClosedStatus `json:"ping_status,omitempty"`
-	Format            Format           `json:"format,omitempty"`
-	Meta              map[string]any   `json:"meta,omitempty"`
-	Sticky            bool             `json:"sticky,omitempty"`
-	Template          string           `json:"template,omitempty"`
-	Categories        []int            `json:"categories,omitempty"`
-	Tags              []int            `json:"tags,omitempty"`
-}
-
-type PostData struct {
-	ID            int              `json:"id,omitempty"`
-	Date          *Date            `json:"date,omitempty"`
-	DateGMT       *Date            `json:"date_gmt,omitempty"`
-	Slug          string           `json:"slug,omitempty"`
-	Status        PostStatus       `json:"status,omitempty"`
-	Password      string           `json:"password,omitempty"`
-	Title         string           `json:"title,omitempty"`
-	Content       string           `json:"content,omitempty"`
-	Author        int              `json:"author,omitempty"`
-	Excerpt       string           `json:"excerpt,omitempty"`
-	FeaturedMedia int              `json:"featured_media,omitempty"`
-	CommentStatus OpenClosedStatus `json:"comment_status,omitempty"`
-	PingStatus    OpenClosedStatus `json:"ping_status,omitempty"`
-	Format        Format           `json:"format,omitempty"`
-	Meta          map[string]any   `json:"meta,omitempty"`
-	Sticky        bool             `json:"sticky,omitempty"`
-	Template      string           `json:"template,omitempty"`
-	Categories    []int            `json:"categories,omitempty"`
-	Tags          []int            `json:"tags,omitempty"`
-}
-
-type Posts struct {
-	client *RestClient
-}
-
-func (c *RestClient) Posts() *Posts {
-	return &Posts{client: c}
-}
-
-func (api *Posts) Revisions(parentID int) *PostRevisions {
-	return &PostRevisions{
-		client:   api.client,
-		parentID: parentID,
-	}
-}
-
-type ListPosts struct {
-	endpoint  string
-	client    *RestClient
-	arguments map[string]string
-}
-
-func (api *Posts) List() *ListPosts {
-	return &ListPosts{
-		endpoint:  "/wp/v2/posts",
-		client:    api.client,
-		arguments: make(map[string]string),
-	}
-}
-
-func (api *ListPosts) ContextView() *ListPosts {
-	api.arguments["context"] = "view"
-	return api
-}
-
-func (api *ListPosts) ContextEdit() *ListPosts {
-	api.arguments["context"] = "edit"
-	return api
-}
-
-func (api *ListPosts) ContextEmbed() *ListPosts {
-	api.arguments["context"] = "embed"
-	return api
-}
-
-func (api *ListPosts) Page(page int) *ListPosts {
-	api.arguments["page"] = strconv.Itoa(page)
-	return api
-}
-
-func (api *ListPosts) PerPage(perPage int) *ListPosts {
-	api.arguments["per_page"] = strconv.Itoa(perPage)
-	return api
-}
-
-func (api *ListPosts) Search(query string) *ListPosts {
-	api.arguments["search"] = query
-	return api
-}
-
-func (api *ListPosts) After(after time.Time) *ListPosts {
-	api.arguments["after"] = after.Format(time.RFC3339)
-	return api
-}
-
-func (api *ListPosts) ModifiedAfter(modifiedAfter time.Time) *ListPosts {
-	api.arguments["modified_after"] = modifiedAfter.Format(time.RFC3339)
-	return api
-}
-
-func (api *ListPosts) Author(authorID int) *ListPosts {
-	api.arguments["author"] = strconv.Itoa(authorID)
-	return api
-}
-
-func (api *ListPosts) AuthorExclude(authorIDs ...int) *ListPosts {
-	authors := []string{}
-
-	for _, authorId := range authorIDs {
-		authors = append(authors, strconv.Itoa(authorId))
-	}
-
-	api.arguments["author_exclude"] = strings.Join(authors, ",")
-	return api
-}
-
-func (api *ListPosts) Before(before time.Time) *ListPosts {
-	api.arguments["before"] = before.Format(time.RFC3339)
-	return api
-}
-
-func (api *ListPosts) ModifiedBefore(modifiedBefore time.Time) *ListPosts {
-	api.arguments["modified_before"] = modifiedBefore.Format(time.RFC3339)
-	return api
-}
-
-func (api *ListPosts) Exclude(excludeIDs ...int) *ListPosts {
-	excludes := []string{}
-	for _, excludeId := range excludeIDs {
-		excludes = append(excludes, strconv.Itoa(excludeId))
-	}
-	api.arguments["exclude"] = strings.Join(excludes, ",")
-	return api
-}
-
-func (api *ListPosts) Include(includeIDs ...int) *ListPosts {
-	includes := []string{}
-	for _, includeId := range includeIDs {
-		includes = append(includes, strconv.Itoa(includeId))
-	}
-	api.arguments["include"] = strings.Join(includes, ",")
-	return api
-}
-
-func (api *ListPosts) Offset(offset int) *ListPosts {
-	api.arguments["offset"] = strconv.Itoa(offset)
-	return api
-}
-
-func (api *ListPosts) OrderAsc() *ListPosts {
-	api.arguments["order"] = "asc"
-	return api
-}
-
-func (api *ListPosts) OrderDesc() *ListPosts {
-	api.arguments["order"] = "desc"
-	return api
-}
-
-func (api *ListPosts) OrderByAuthor() *ListPosts {
-	api.arguments["orderby"] = "author"
-	return api
-}
-
-func (api *ListPosts) OrderByDate() *ListPosts {
-	api.arguments["orderby"] = "date"
-	return api
-}
-
-func (api *ListPosts) OrderById() *ListPosts {
-	api.arguments["orderby"] = "id"
-	return api
-}
-
-func (api *ListPosts) OrderByInclude() *ListPosts {
-	api.arguments["orderby"] = "include"
-	return api
-}
-
-func (api *ListPosts) OrderByModified() *ListPosts {
-	api.arguments["orderby"] = "modified"
-	return api
-}
-
-func (api *ListPosts) OrderByParent() *ListPosts {
-	api.arguments["orderby"] = "parent"
-	return api
-}
-
-func (api *ListPosts) OrderByRelevance() *ListPosts {
-	api.arguments["orderby"] = "relevance"
-	return api
-}
-
-func (api *ListPosts) OrderBySlug() *ListPosts {
-	api.arguments["orderby"] = "slug"
-	return api
-}
-
-func (api *ListPosts) OrderByIncludeSlug() *ListPosts {
-	api.arguments["orderby"] = "include_slugs"
-	return api
-}
-
-func (api *ListPosts) OrderByTitle() *ListPosts {
-	api.arguments["orderby"] = "title"
-	return api
-}
-
-func (api *ListPosts) SearchColumns(columns ...string) *ListPosts {
-	api.arguments["search_columns"] = strings.Join(columns, ",")
-	return api
-}
-
-func (api *ListPosts) Slug(slug string) *ListPosts {
-	api.arguments["slug"] = slug
-	return api
-}
-
-func (api *ListPosts) StatusPublish() *ListPosts {
-	api.arguments["status"] = "publish"
-	return api
-}
-
-func (api *ListPosts) StatusDraft() *ListPosts {
-	api.arguments["status"] = "draft"
-	return api
-}
-
-func (api *ListPosts) StatusPending() *ListPosts {
-	api.arguments["status"] = "pending"
-	return api
-}
-
-func (api *ListPosts) StatusPrivate() *ListPosts {
-	api.arguments["status"] = "private"
-	return api
-}
-
-func (api *ListPosts) StatusFuture() *ListPosts {
-	api.arguments["status"] = "future"
-	return api
-}
-
-func (api *ListPosts) StatusTrash() *ListPosts {
-	api.arguments["status"] = "trash"
-	return api
-}
-
-func (api *ListPosts) StatusAny() *ListPosts {
-	api.arguments["status"] = "any"
-	return api
-}
-
-func (api *ListPosts) TaxAnd() *ListPosts {
-	api.arguments["tax_relation"] = "AND"
-	return api
-}
-
-func (api *ListPosts) TaxOr() *ListPosts {
-	api.arguments["tax_relation"] = "OR"
-	return api
-}
-
-func (api *ListPosts) Categories(categories ...string) *ListPosts {
-	api.arguments["category"] = strings.Join(categories, ",")
-	return api
-}
-
-func (api *ListPosts) CategoriesExclude(categories ...string) *ListPosts {
-	api.arguments["category_exclude"] = strings.Join(categories, ",")
-	return api
-}
-
-func (api *ListPosts) Tags(tags ...string) *ListPosts {
-	api.arguments["tags"] = strings.Join(tags, ",")
-	return api
-}
-
-func (api *ListPosts) TagsExclude(tags ...string) *ListPosts {
-	api.arguments["tags_exclude"] = strings.Join(tags, ",")
-	return api
-}
-
-func (api *ListPosts) Sticky(sticky bool) *ListPosts {
-	api.arguments["sticky"] = strconv.FormatBool(sticky)
-	return api
-}
-
-func (api *ListPosts) Do() (posts []Post, err error) {
-	_, err = api.client.httpClient.R().
-		SetHeader("Accept", "application/json").
-		SetResult(&posts).
-		SetQueryParams(api.arguments).
-		Get(api.client.endpoint + api.endpoint)
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type CreatePost struct {
-	endpoint string
-	client   *RestClient
-	post     PostData
-}
-
-func (api *Posts) Create(post PostData) *CreatePost {
-	return &CreatePost{
-		endpoint: "/wp/v2/posts",
-		client:   api.client,
-		post:     post,
-	}
-}
-
-func (api *CreatePost) Do() (post Post, err error) {
-	resp, err := api.client.httpClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-		SetResult(&post).
-		SetBody(api.post).
-		// SetError(err).
-		Post(api.client.endpoint + api.endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return post, &wpError
-	}
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type RetrievePost struct {
-	endpoint  string
-	client    *RestClient
-	arguments map[string]string
-}
-
-func (api *Posts) Retrieve(postId int) *RetrievePost {
-	return &RetrievePost{
-		endpoint:  "/wp/v2/posts/" + strconv.Itoa(postId),
-		client:    api.client,
-		arguments: make(map[string]string),
-	}
-}
-
-func (api *RetrievePost) ContextView() *RetrievePost {
-	api.arguments["context"] = "view"
-	return api
-}
-
-func (api *RetrievePost) ContextEdit() *RetrievePost {
-	api.arguments["context"] = "edit"
-	return api
-}
-
-func (api *RetrievePost) ContextEmbed() *RetrievePost {
-	api.arguments["context"] = "embed"
-	return api
-}
-
-func (api *RetrievePost) Password(password string) *RetrievePost {
-	api.arguments["password"] = password
-	return api
-}
-
-func (api *RetrievePost) Do() (post *Post, err error) {
-	endpoint := api.client.endpoint + api.endpoint
-
-	restyClient := api.client.httpClient.R()
-	if api.client.auth.Username != "" && api.client.auth.Password != "" && api.arguments["context"] == "edit" {
-		restyClient.SetBasicAuth(api.client.auth.Username, api.client.auth.Password)
-	}
-
-	resp, err := restyClient.
-		SetHeader("Accept", "application/json").
-		SetResult(&post).
-		SetQueryParams(api.arguments).
-		Get(endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return post, &wpError
-	}
-
-	// TODO: need fixing of message = invalid suit value: trash
-	if err != nil && err.Error() == "invalid suit value: trash" {
-		err = nil
-	}
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type UpdatePost struct {
-	endpoint string
-	client   *RestClient
-	post     PostData
-}
-
-func (api *Posts) Update(post PostData) *UpdatePost {
-	return &UpdatePost{
-		endpoint: "/wp/v2/posts/" + strconv.Itoa(post.ID),
-		client:   api.client,
-		post:     post,
-	}
-}
-
-func (api *UpdatePost) Do() (post Post, err error) {
-	resp, err := api.client.httpClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-		SetResult(&post).
-		SetBody(api.post).
-		// SetError(err).
-		Post(api.client.endpoint + api.endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return post, &wpError
-	}
-
-	// TODO: need fixing of message = invalid suit value: trash
-	if err != nil && err.Error() == "invalid suit value: trash" {
-		err = nil
-	}
-
-	if err != nil {
-		return
-	}
-
-	return
-}
-
-type DeletePost struct {
-	endpoint string
-	client   *RestClient
-	postId   int
-	force    bool
-}
-
-func (api *Posts) Delete(postId int) *DeletePost {
-	return &DeletePost{
-		endpoint: "/wp/v2/posts",
-		client:   api.client,
-		postId:   postId,
-	}
-}
-
-func (api *DeletePost) Force() *DeletePost {
-	api.force = true
-	return api
-}
-
-func (api *DeletePost) Do() (post Post, err error) {
-	endpoint := api.client.endpoint + api.endpoint + "/" + strconv.Itoa(api.postId)
-	resp, err :=
-		api.client.httpClient.R().
-			SetHeader("Content-Type", "application/json").
-			SetBasicAuth(api.client.auth.Username, api.client.auth.Password).
-			SetResult(&post).
-			SetQueryParam("force", strconv.FormatBool(api.force)).
-			Delete(endpoint)
-
-	if resp.IsError() {
-		var wpError WPRestError
-		err = json.Unmarshal(resp.Bytes(), &wpError)
-
-		if err != nil {
-			return
-		}
-
-		return post, &wpError
-	}
-
-	// TODO: need fixing of message = invalid suit value: trash
-	if err != nil && err.Error() == "invalid suit value: trash" {
-		err = nil
-	}
-
-	return
-}
+package gowprest
+
+import (
+	"encoding/json"
+	"maps"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Object is a field WordPress sends both as stored and as rendered through
+// its filters, such as a title or content. Raw and BlockVersion are only
+// sent in the edit context.
+type Object struct {
+	Raw          string `json:"raw,omitempty"`
+	Rendered     string `json:"rendered"`
+	Protected    bool   `json:"protected,omitempty,omitzero"`
+	BlockVersion int    `json:"block_version,omitempty"`
+}
+
+// raw returns the stored value of o, or "" when o is nil.
+func (o *Object) raw() string {
+	if o == nil {
+		return ""
+	}
+	return o.Raw
+}
+
+type Post struct {
+	Date              *Date            `json:"date,omitempty"`
+	DateGMT           *Date            `json:"date_gmt,omitempty"`
+	GUID              *Object          `json:"guid,omitempty"`
+	ID                int              `json:"id,omitempty"`
+	Link              string           `json:"link,omitempty"`
+	Modified          *Date            `json:"modified,omitempty"`
+	ModifiedGMT       *Date            `json:"modified_gmt,omitempty"`
+	Slug              string           `json:"slug,omitempty"`
+	Status            PostStatus       `json:"status,omitempty"`
+	Type              string           `json:"type,omitempty"`
+	Password          string           `json:"password,omitempty"`
+	PermalinkTemplate string           `json:"permalink_template,omitempty"`
+	GeneratedSlug     string           `json:"generated_slug,omitempty"`
+	Title             *Object          `json:"title,omitempty"`
+	Content           *Object          `json:"content,omitempty"`
+	Author            int              `json:"author,omitempty"`
+	Excerpt           *Object          `json:"excerpt,omitempty"`
+	FeaturedMedia     int              `json:"featured_media,omitempty"`
+	CommentStatus     OpenClosedStatus `json:"comment_status,omitempty"`
+	PingStatus        OpenClosedStatus `json:"ping_status,omitempty"`
+	Format            Format           `json:"format,omitempty"`
+	Meta              map[string]any   `json:"meta,omitempty"`
+	Sticky            bool             `json:"sticky,omitempty"`
+	Template          string           `json:"template,omitempty"`
+	Categories        []int            `json:"categories,omitempty"`
+	Tags              []int            `json:"tags,omitempty"`
+
+	// Extras holds the fields the model does not declare.
+	Extras Extras `json:"-"`
+}
+
+type PostData struct {
+	ID            int              `json:"id,omitempty"`
+	Date          *Date            `json:"date,omitempty"`
+	DateGMT       *Date            `json:"date_gmt,omitempty"`
+	Slug          string           `json:"slug,omitempty"`
+	Status        PostStatus       `json:"status,omitempty"`
+	Password      string           `json:"password,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	Content       string           `json:"content,omitempty"`
+	Author        int              `json:"author,omitempty"`
+	Excerpt       string           `json:"excerpt,omitempty"`
+	FeaturedMedia int              `json:"featured_media,omitempty"`
+	CommentStatus OpenClosedStatus `json:"comment_status,omitempty"`
+	PingStatus    OpenClosedStatus `json:"ping_status,omitempty"`
+	Format        Format           `json:"format,omitempty"`
+	Meta          map[string]any   `json:"meta,omitempty"`
+	Sticky        bool             `json:"sticky,omitempty"`
+	Template      string           `json:"template,omitempty"`
+	Categories    []int            `json:"categories,omitempty"`
+	Tags          []int            `json:"tags,omitempty"`
+}
+
+// Data returns the post as PostData carrying the raw title, content and
+// excerpt, so a post retrieved with ContextEdit can be modified and written
+// back with Update without WordPress filters being applied twice. Fields
+// that were not sent, as raw values are not in the view context, stay empty
+// and are left untouched by Update. The date of a draft that has none yet
+// is not carried over, so it keeps following the publication time.
+func (p Post) Data() PostData {
+	data := PostData{
+		ID:            p.ID,
+		Slug:          p.Slug,
+		Status:        p.Status,
+		Password:      p.Password,
+		Title:         p.Title.raw(),
+		Content:       p.Content.raw(),
+		Author:        p.Author,
+		Excerpt:       p.Excerpt.raw(),
+		FeaturedMedia: p.FeaturedMedia,
+		CommentStatus: p.CommentStatus,
+		PingStatus:    p.PingStatus,
+		Format:        p.Format,
+		Meta:          p.Meta,
+		Sticky:        p.Sticky,
+		Template:      p.Template,
+		Categories:    p.Categories,
+		Tags:          p.Tags,
+	}
+
+	if p.DateGMT != nil && !p.DateGMT.IsZero() {
+		data.DateGMT = p.DateGMT
+	}
+
+	return data
+}
+
+type Posts struct {
+	client *RestClient
+}
+
+func (c *RestClient) Posts() *Posts {
+	return &Posts{client: c}
+}
+
+func (api *Posts) Revisions(parentID int) *PostRevisions {
+	return &PostRevisions{
+		client:   api.client,
+		parentID: parentID,
+	}
+}
+
+type ListPosts struct {
+	endpoint  string
+	client    *RestClient
+	arguments url.Values
+}
+
+func (api *ListPosts) Clone() *ListPosts {
+	clone := *api
+	clone.arguments = cloneValues(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListPosts) With(options ...RequestOption) *ListPosts {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Posts) List() *ListPosts {
+	return &ListPosts{
+		endpoint:  "/wp/v2/posts",
+		client:    api.client,
+		arguments: url.Values{},
+	}
+}
+
+func (api *ListPosts) ContextView() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("context", "view")
+	return api
+}
+
+func (api *ListPosts) ContextEdit() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("context", "edit")
+	return api
+}
+
+func (api *ListPosts) ContextEmbed() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("context", "embed")
+	return api
+}
+
+func (api *ListPosts) Page(page int) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("page", strconv.Itoa(page))
+	return api
+}
+
+func (api *ListPosts) PerPage(perPage int) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("per_page", strconv.Itoa(perPage))
+	return api
+}
+
+func (api *ListPosts) Search(query string) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("search", query)
+	return api
+}
+
+func (api *ListPosts) After(after time.Time) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("after", after.Format(time.RFC3339))
+	return api
+}
+
+func (api *ListPosts) ModifiedAfter(modifiedAfter time.Time) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("modified_after", modifiedAfter.Format(time.RFC3339))
+	return api
+}
+
+// Author limits the result to posts by any of the given authors.
+func (api *ListPosts) Author(authorIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "author", authorIDs...)
+	return api
+}
+
+func (api *ListPosts) AuthorExclude(authorIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "author_exclude", authorIDs...)
+	return api
+}
+
+func (api *ListPosts) Before(before time.Time) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("before", before.Format(time.RFC3339))
+	return api
+}
+
+func (api *ListPosts) ModifiedBefore(modifiedBefore time.Time) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("modified_before", modifiedBefore.Format(time.RFC3339))
+	return api
+}
+
+func (api *ListPosts) Exclude(excludeIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "exclude", excludeIDs...)
+	return api
+}
+
+func (api *ListPosts) Include(includeIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "include", includeIDs...)
+	return api
+}
+
+func (api *ListPosts) Offset(offset int) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("offset", strconv.Itoa(offset))
+	return api
+}
+
+func (api *ListPosts) OrderAsc() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("order", "asc")
+	return api
+}
+
+func (api *ListPosts) OrderDesc() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("order", "desc")
+	return api
+}
+
+func (api *ListPosts) OrderByAuthor() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "author")
+	return api
+}
+
+func (api *ListPosts) OrderByDate() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "date")
+	return api
+}
+
+func (api *ListPosts) OrderById() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "id")
+	return api
+}
+
+func (api *ListPosts) OrderByInclude() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "include")
+	return api
+}
+
+func (api *ListPosts) OrderByModified() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "modified")
+	return api
+}
+
+func (api *ListPosts) OrderByParent() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "parent")
+	return api
+}
+
+func (api *ListPosts) OrderByRelevance() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "relevance")
+	return api
+}
+
+func (api *ListPosts) OrderBySlug() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "slug")
+	return api
+}
+
+func (api *ListPosts) OrderByIncludeSlug() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "include_slugs")
+	return api
+}
+
+func (api *ListPosts) OrderByTitle() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("orderby", "title")
+	return api
+}
+
+func (api *ListPosts) SearchColumns(columns ...string) *ListPosts {
+	api = api.Clone()
+	setList(api.arguments, "search_columns", columns...)
+	return api
+}
+
+func (api *ListPosts) Slug(slug string) *ListPosts {
+	return api.Slugs(slug)
+}
+
+// Slugs limits the result to posts with any of the given slugs.
+func (api *ListPosts) Slugs(slugs ...string) *ListPosts {
+	api = api.Clone()
+	setList(api.arguments, "slug", slugs...)
+	return api
+}
+
+func (api *ListPosts) StatusPublish() *ListPosts {
+	return api.StatusIn(StatusPublished)
+}
+
+func (api *ListPosts) StatusDraft() *ListPosts {
+	return api.StatusIn(StatusDraft)
+}
+
+func (api *ListPosts) StatusPending() *ListPosts {
+	return api.StatusIn(StatusPending)
+}
+
+func (api *ListPosts) StatusPrivate() *ListPosts {
+	return api.StatusIn(StatusPrivate)
+}
+
+func (api *ListPosts) StatusFuture() *ListPosts {
+	return api.StatusIn(StatusFuture)
+}
+
+func (api *ListPosts) StatusTrash() *ListPosts {
+	return api.StatusIn(StatusTrash)
+}
+
+func (api *ListPosts) StatusAny() *ListPosts {
+	return api.StatusIn("any")
+}
+
+// StatusIn limits the result to posts with any of the given statuses.
+func (api *ListPosts) StatusIn(statuses ...PostStatus) *ListPosts {
+	api = api.Clone()
+	values := []string{}
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+	setList(api.arguments, "status", values...)
+	return api
+}
+
+func (api *ListPosts) TaxAnd() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("tax_relation", "AND")
+	return api
+}
+
+func (api *ListPosts) TaxOr() *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("tax_relation", "OR")
+	return api
+}
+
+// Categories limits the result to posts in any of the given categories.
+func (api *ListPosts) Categories(categoryIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "categories", categoryIDs...)
+	return api
+}
+
+func (api *ListPosts) CategoriesExclude(categoryIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "categories_exclude", categoryIDs...)
+	return api
+}
+
+// CategoriesQuery limits the result to posts matching query, which can
+// also match subcategories or require every category.
+func (api *ListPosts) CategoriesQuery(query TaxQuery) *ListPosts {
+	api = api.Clone()
+	setTaxQuery(api.arguments, "categories", query)
+	return api
+}
+
+func (api *ListPosts) CategoriesExcludeQuery(query TaxQuery) *ListPosts {
+	api = api.Clone()
+	setTaxQuery(api.arguments, "categories_exclude", query)
+	return api
+}
+
+// Tags limits the result to posts with any of the given tags.
+func (api *ListPosts) Tags(tagIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "tags", tagIDs...)
+	return api
+}
+
+func (api *ListPosts) TagsExclude(tagIDs ...int) *ListPosts {
+	api = api.Clone()
+	setIDs(api.arguments, "tags_exclude", tagIDs...)
+	return api
+}
+
+func (api *ListPosts) TagsQuery(query TaxQuery) *ListPosts {
+	api = api.Clone()
+	setTaxQuery(api.arguments, "tags", query)
+	return api
+}
+
+// Taxonomy limits the result to posts matching query in the taxonomy
+// exposed under restBase, such as a custom taxonomy registered with
+// show_in_rest. Combine several with TaxAnd or TaxOr.
+func (api *ListPosts) Taxonomy(restBase string, query TaxQuery) *ListPosts {
+	api = api.Clone()
+	setTaxQuery(api.arguments, restBase, query)
+	return api
+}
+
+func (api *ListPosts) TaxonomyExclude(restBase string, query TaxQuery) *ListPosts {
+	api = api.Clone()
+	setTaxQuery(api.arguments, restBase+"_exclude", query)
+	return api
+}
+
+func (api *ListPosts) Sticky(sticky bool) *ListPosts {
+	api = api.Clone()
+	api.arguments.Set("sticky", strconv.FormatBool(sticky))
+	return api
+}
+
+func (api *ListPosts) Do() (posts []Post, err error) {
+	restyClient := api.client.request()
+	if api.arguments.Get("context") == "edit" || api.arguments.Has("status[]") {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&posts).
+		SetQueryParamsFromValues(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return posts, &wpError
+	}
+
+	return
+}
+
+type CreatePost struct {
+	endpoint string
+	client   *RestClient
+	post     PostData
+}
+
+func (api *CreatePost) Clone() *CreatePost {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *CreatePost) With(options ...RequestOption) *CreatePost {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Posts) Create(post PostData) *CreatePost {
+	return &CreatePost{
+		endpoint: "/wp/v2/posts",
+		client:   api.client,
+		post:     post,
+	}
+}
+
+func (api *CreatePost) Do() (post Post, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&post).
+		SetBody(api.post).
+		// SetError(err).
+		Post(api.client.url(api.endpoint))
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return post, &wpError
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+type RetrievePost struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *RetrievePost) Clone() *RetrievePost {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrievePost) With(options ...RequestOption) *RetrievePost {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Posts) Retrieve(postId int) *RetrievePost {
+	return &RetrievePost{
+		endpoint:  "/wp/v2/posts/" + strconv.Itoa(postId),
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *RetrievePost) ContextView() *RetrievePost {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *RetrievePost) ContextEdit() *RetrievePost {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *RetrievePost) ContextEmbed() *RetrievePost {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *RetrievePost) Password(password string) *RetrievePost {
+	api = api.Clone()
+	api.arguments["password"] = password
+	return api
+}
+
+func (api *RetrievePost) Do() (post *Post, err error) {
+	endpoint := api.client.url(api.endpoint)
+
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&post).
+		SetQueryParams(api.arguments).
+		Get(endpoint)
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return post, &wpError
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+type UpdatePost struct {
+	endpoint string
+	client   *RestClient
+	post     PostData
+
+	unmodifiedSince *time.Time
+}
+
+func (api *UpdatePost) Clone() *UpdatePost {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdatePost) With(options ...RequestOption) *UpdatePost {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Posts) Update(post PostData) *UpdatePost {
+	return &UpdatePost{
+		endpoint: "/wp/v2/posts/" + strconv.Itoa(post.ID),
+		client:   api.client,
+		post:     post,
+	}
+}
+
+// IfUnmodifiedSince makes Do re-read the post first and fail with a
+// ConflictError[Post, PostData] instead of writing when it was modified after
+// since, usually the ModifiedGMT of the copy being edited. The check narrows
+// the window for lost updates but cannot close it, as WordPress has no
+// conditional writes. When the post read back has no modified_gmt, Do fails
+// with ErrModifiedUnknown rather than write unchecked.
+func (api *UpdatePost) IfUnmodifiedSince(since time.Time) *UpdatePost {
+	api = api.Clone()
+	api.unmodifiedSince = &since
+	return api
+}
+
+func (api *UpdatePost) Do() (post Post, err error) {
+	if api.unmodifiedSince != nil {
+		var current *Post
+		current, err = api.client.Posts().Retrieve(api.post.ID).ContextEdit().Do()
+		if err != nil {
+			return
+		}
+
+		err = checkUnmodified(current, modifiedGMTOfPost, *api.unmodifiedSince, api.post)
+		if err != nil {
+			return
+		}
+	}
+
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&post).
+		SetBody(api.post).
+		// SetError(err).
+		Post(api.client.url(api.endpoint))
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return post, &wpError
+	}
+
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// PatchPost updates only the fields set on it. Unlike Update, which skips
+// zero values, a field set to its zero value is sent as such, so Sticky(false),
+// Categories() or Excerpt("") clear the stored value.
+type PatchPost struct {
+	endpoint string
+	client   *RestClient
+	postID   int
+	fields   map[string]any
+
+	unmodifiedSince *time.Time
+}
+
+func (api *PatchPost) Clone() *PatchPost {
+	clone := *api
+	clone.fields = cloneFields(api.fields)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *PatchPost) With(options ...RequestOption) *PatchPost {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Posts) Patch(postID int) *PatchPost {
+	return &PatchPost{
+		endpoint: "/wp/v2/posts/" + strconv.Itoa(postID),
+		client:   api.client,
+		postID:   postID,
+		fields:   make(map[string]any),
+	}
+}
+
+// Set sends value for the field as is; a nil value is sent as null.
+func (api *PatchPost) Set(field string, value any) *PatchPost {
+	api = api.Clone()
+	api.fields[field] = value
+	return api
+}
+
+// Date sets the publication date; nil sends null.
+func (api *PatchPost) Date(date *Date) *PatchPost {
+	return api.Set("date", date)
+}
+
+// DateGMT sets the publication date in UTC; nil sends null.
+func (api *PatchPost) DateGMT(date *Date) *PatchPost {
+	return api.Set("date_gmt", date)
+}
+
+func (api *PatchPost) Slug(slug string) *PatchPost {
+	return api.Set("slug", slug)
+}
+
+func (api *PatchPost) Status(status PostStatus) *PatchPost {
+	return api.Set("status", status)
+}
+
+func (api *PatchPost) Password(password string) *PatchPost {
+	return api.Set("password", password)
+}
+
+func (api *PatchPost) Title(title string) *PatchPost {
+	return api.Set("title", title)
+}
+
+func (api *PatchPost) Content(content string) *PatchPost {
+	return api.Set("content", content)
+}
+
+func (api *PatchPost) Author(authorID int) *PatchPost {
+	return api.Set("author", authorID)
+}
+
+func (api *PatchPost) Excerpt(excerpt string) *PatchPost {
+	return api.Set("excerpt", excerpt)
+}
+
+// FeaturedMedia sets the featured image; 0 removes it.
+func (api *PatchPost) FeaturedMedia(mediaID int) *PatchPost {
+	return api.Set("featured_media", mediaID)
+}
+
+func (api *PatchPost) CommentStatus(status OpenClosedStatus) *PatchPost {
+	return api.Set("comment_status", status)
+}
+
+func (api *PatchPost) PingStatus(status OpenClosedStatus) *PatchPost {
+	return api.Set("ping_status", status)
+}
+
+func (api *PatchPost) Format(format Format) *PatchPost {
+	return api.Set("format", format)
+}
+
+// Meta sets a single meta key, leaving the others untouched; nil deletes it.
+func (api *PatchPost) Meta(key string, value any) *PatchPost {
+	api = api.Clone()
+	meta, _ := api.fields["meta"].(map[string]any)
+	if meta == nil {
+		meta = make(map[string]any)
+		api.fields["meta"] = meta
+	}
+	meta[key] = value
+	return api
+}
+
+func (api *PatchPost) Sticky(sticky bool) *PatchPost {
+	return api.Set("sticky", sticky)
+}
+
+func (api *PatchPost) Template(template string) *PatchPost {
+	return api.Set("template", template)
+}
+
+// Categories replaces the post's categories; no IDs removes them all.
+func (api *PatchPost) Categories(categoryIDs ...int) *PatchPost {
+	return api.Set("categories", append([]int{}, categoryIDs...))
+}
+
+// Tags replaces the post's tags; no IDs removes them all.
+func (api *PatchPost) Tags(tagIDs ...int) *PatchPost {
+	return api.Set("tags", append([]int{}, tagIDs...))
+}
+
+// IfUnmodifiedSince guards the write like UpdatePost.IfUnmodifiedSince; a
+// conflict returns a ConflictError[Post, map[string]any] holding the fields.
+func (api *PatchPost) IfUnmodifiedSince(since time.Time) *PatchPost {
+	api = api.Clone()
+	api.unmodifiedSince = &since
+	return api
+}
+
+func (api *PatchPost) Do() (post Post, err error) {
+	if api.unmodifiedSince != nil {
+		var current *Post
+		current, err = api.client.Posts().Retrieve(api.postID).ContextEdit().Do()
+		if err != nil {
+			return
+		}
+
+		err = checkUnmodified(current, modifiedGMTOfPost, *api.unmodifiedSince, api.fields)
+		if err != nil {
+			return
+		}
+	}
+
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&post).
+		SetBody(api.fields).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return post, &wpError
+	}
+
+	return
+}
+
+// UpsertPost creates the post or updates the one with the same slug, so an
+// import can be run again without duplicating posts. Drafts, pending and
+// private posts count as matches; trashed posts only with RestoreTrashed.
+type UpsertPost struct {
+	client  *RestClient
+	post    PostData
+	trashed bool
+}
+
+func (api *UpsertPost) Clone() *UpsertPost {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpsertPost) With(options ...RequestOption) *UpsertPost {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Upsert creates or updates post, matched by post.Slug.
+func (api *Posts) Upsert(post PostData) *UpsertPost {
+	return &UpsertPost{
+		client: api.client,
+		post:   post,
+	}
+}
+
+// RestoreTrashed takes a trashed post with the slug as the match when no
+// other post has it, and restores it instead of creating a new post next to
+// it. The restored post becomes a draft unless the data sets a status.
+func (api *UpsertPost) RestoreTrashed() *UpsertPost {
+	api = api.Clone()
+	api.trashed = true
+	return api
+}
+
+// Do reports with created whether the post was created or updated.
+func (api *UpsertPost) Do() (post Post, created bool, err error) {
+	data := api.post
+	if data.Slug == "" {
+		err = errSlugRequired
+		return
+	}
+
+	matches, err := findBySlug[Post](api.client, "/wp/v2/posts", map[string]string{
+		"slug":   data.Slug,
+		"status": "any",
+	})
+	if err != nil {
+		return
+	}
+
+	if len(matches) == 0 && api.trashed {
+		matches, err = findBySlug[Post](api.client, "/wp/v2/posts", map[string]string{
+			"slug":   data.Slug + trashedSuffix,
+			"status": string(StatusTrash),
+		})
+		if err != nil {
+			return
+		}
+		if data.Status == "" {
+			data.Status = StatusDraft
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		post, err = api.client.Posts().Create(data).Do()
+		return post, err == nil, err
+	case 1:
+		data.ID = matches[0].ID
+		post, err = api.client.Posts().Update(data).Do()
+		return
+	}
+
+	err = ErrAmbiguousSlug
+	return
+}
+
+type DeletePost struct {
+	endpoint string
+	client   *RestClient
+	postId   int
+	force    bool
+}
+
+func (api *DeletePost) Clone() *DeletePost {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeletePost) With(options ...RequestOption) *DeletePost {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Posts) Delete(postId int) *DeletePost {
+	return &DeletePost{
+		endpoint: "/wp/v2/posts",
+		client:   api.client,
+		postId:   postId,
+	}
+}
+
+func (api *DeletePost) Force() *DeletePost {
+	api = api.Clone()
+	api.force = true
+	return api
+}
+
+// Do deletes the post. Without Force the post is moved to the trash and
+// returned as is; with Force the API answers with the previous state, which
+// is unwrapped so both cases return the same model.
+func (api *DeletePost) Do() (post Post, err error) {
+	endpoint := api.client.url(api.endpoint + "/" + strconv.Itoa(api.postId))
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("force", strconv.FormatBool(api.force)).
+		Delete(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return post, &wpError
+	}
+
+	if api.force {
+		var nested struct {
+			Deleted  bool `json:"deleted"`
+			Previous Post `json:"previous"`
+		}
+		err = unmarshal(resp.Bytes(), &nested)
+		if err != nil {
+			return
+		}
+		return nested.Previous, nil
+	}
+
+	err = unmarshal(resp.Bytes(), &post)
+	return
+}
@@ -0,0 +1,76 @@
+package gowprest
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// TaxQuery filters posts by the terms of one taxonomy.
+type TaxQuery struct {
+	// Terms are the term IDs to match.
+	Terms []int
+	// IncludeChildren also matches the descendants of Terms, for
+	// hierarchical taxonomies such as categories.
+	IncludeChildren bool
+	// Operator is "AND" to require every term, or "OR", the default, to
+	// require any of them. WordPress ignores it when excluding.
+	Operator string
+}
+
+// setList sets key to values as an array argument, key[]=a&key[]=b,
+// replacing any earlier value of key in any form, including the object form
+// of setTaxQuery.
+func setList(arguments url.Values, key string, values ...string) {
+	arguments.Del(key)
+	arguments.Del(key + "[]")
+	arguments.Del(key + "[terms][]")
+	arguments.Del(key + "[include_children]")
+	arguments.Del(key + "[operator]")
+	if len(values) > 0 {
+		arguments[key+"[]"] = values
+	}
+}
+
+// setIDs sets key to ids as an array argument.
+func setIDs(arguments url.Values, key string, ids ...int) {
+	values := []string{}
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	setList(arguments, key, values...)
+}
+
+// setTaxQuery sets key to query as an object argument,
+// key[terms][]=1&key[include_children]=true&key[operator]=AND, replacing any
+// earlier list of term IDs.
+func setTaxQuery(arguments url.Values, key string, query TaxQuery) {
+	setList(arguments, key)
+	setIDs(arguments, key+"[terms]", query.Terms...)
+	if query.IncludeChildren {
+		arguments.Set(key+"[include_children]", "true")
+	}
+	if query.Operator != "" {
+		arguments.Set(key+"[operator]", query.Operator)
+	}
+}
+
+// cloneValues returns a deep copy of arguments.
+func cloneValues(arguments url.Values) url.Values {
+	clone := make(url.Values, len(arguments))
+	for key, values := range arguments {
+		clone[key] = slices.Clone(values)
+	}
+	return clone
+}
+
+// cloneFields returns a copy of the fields of a patch builder, with its
+// meta map copied as well.
+func cloneFields(fields map[string]any) map[string]any {
+	clone := maps.Clone(fields)
+	if meta, isMap := clone["meta"].(map[string]any); isMap {
+		clone["meta"] = maps.Clone(meta)
+	}
+	return clone
+}
@@ -0,0 +1,504 @@
+package gowprest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resource is a generic CRUD client for a post type exposed under its
+// rest_base, such as the "product" or "event" types registered by plugins.
+// T is the model returned by the API and D is the payload sent on create and
+// update; both are usually caller-defined structs that embed Post and
+// PostData to add their extra fields.
+type Resource[T any, D any] struct {
+	client   *RestClient
+	endpoint string
+}
+
+// NewResource returns a Resource for the given rest_base in the wp/v2 namespace.
+func NewResource[T any, D any](client *RestClient, restBase string) *Resource[T, D] {
+	return &Resource[T, D]{
+		client:   client,
+		endpoint: "/wp/v2/" + strings.Trim(restBase, "/"),
+	}
+}
+
+// NewResourceFor returns a Resource for a post type as described by the types endpoint.
+func NewResourceFor[T any, D any](client *RestClient, postType PostType) *Resource[T, D] {
+	namespace := postType.RestNamespace
+	if namespace == "" {
+		namespace = "wp/v2"
+	}
+
+	restBase := postType.RestBase
+	if restBase == "" {
+		restBase = postType.Slug
+	}
+
+	return &Resource[T, D]{
+		client:   client,
+		endpoint: "/" + strings.Trim(namespace, "/") + "/" + strings.Trim(restBase, "/"),
+	}
+}
+
+// ErrPostTypeUndescribed is returned by DiscoverResource when the types
+// endpoint answers without describing the post type.
+var ErrPostTypeUndescribed = errors.New("gowprest: the site did not describe the post type")
+
+// DiscoverResource looks up the post type through the types endpoint and
+// returns a Resource bound to its rest_namespace and rest_base.
+func DiscoverResource[T any, D any](client *RestClient, postType string) (*Resource[T, D], error) {
+	info, err := client.Types().Retrieve(postType).Do()
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPostTypeUndescribed, postType)
+	}
+
+	return NewResourceFor[T, D](client, *info), nil
+}
+
+type ListResource[T any] struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *ListResource[T]) Clone() *ListResource[T] {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListResource[T]) With(options ...RequestOption) *ListResource[T] {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Resource[T, D]) List() *ListResource[T] {
+	return &ListResource[T]{
+		endpoint:  api.endpoint,
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *ListResource[T]) ContextView() *ListResource[T] {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *ListResource[T]) ContextEdit() *ListResource[T] {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *ListResource[T]) ContextEmbed() *ListResource[T] {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *ListResource[T]) Page(page int) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["page"] = strconv.Itoa(page)
+	return api
+}
+
+func (api *ListResource[T]) PerPage(perPage int) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["per_page"] = strconv.Itoa(perPage)
+	return api
+}
+
+func (api *ListResource[T]) Search(query string) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["search"] = query
+	return api
+}
+
+func (api *ListResource[T]) After(after time.Time) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["after"] = after.Format(time.RFC3339)
+	return api
+}
+
+func (api *ListResource[T]) Before(before time.Time) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["before"] = before.Format(time.RFC3339)
+	return api
+}
+
+func (api *ListResource[T]) ModifiedAfter(modifiedAfter time.Time) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["modified_after"] = modifiedAfter.Format(time.RFC3339)
+	return api
+}
+
+func (api *ListResource[T]) ModifiedBefore(modifiedBefore time.Time) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["modified_before"] = modifiedBefore.Format(time.RFC3339)
+	return api
+}
+
+func (api *ListResource[T]) Author(authorID int) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["author"] = strconv.Itoa(authorID)
+	return api
+}
+
+func (api *ListResource[T]) Exclude(excludeIDs ...int) *ListResource[T] {
+	api = api.Clone()
+	excludes := []string{}
+	for _, excludeId := range excludeIDs {
+		excludes = append(excludes, strconv.Itoa(excludeId))
+	}
+	api.arguments["exclude"] = strings.Join(excludes, ",")
+	return api
+}
+
+func (api *ListResource[T]) Include(includeIDs ...int) *ListResource[T] {
+	api = api.Clone()
+	includes := []string{}
+	for _, includeId := range includeIDs {
+		includes = append(includes, strconv.Itoa(includeId))
+	}
+	api.arguments["include"] = strings.Join(includes, ",")
+	return api
+}
+
+func (api *ListResource[T]) Offset(offset int) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["offset"] = strconv.Itoa(offset)
+	return api
+}
+
+func (api *ListResource[T]) OrderAsc() *ListResource[T] {
+	api = api.Clone()
+	api.arguments["order"] = "asc"
+	return api
+}
+
+func (api *ListResource[T]) OrderDesc() *ListResource[T] {
+	api = api.Clone()
+	api.arguments["order"] = "desc"
+	return api
+}
+
+func (api *ListResource[T]) OrderBy(orderBy string) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["orderby"] = orderBy
+	return api
+}
+
+func (api *ListResource[T]) Slug(slug string) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["slug"] = slug
+	return api
+}
+
+func (api *ListResource[T]) Status(status string) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["status"] = status
+	return api
+}
+
+func (api *ListResource[T]) Parent(parentID int) *ListResource[T] {
+	api = api.Clone()
+	api.arguments["parent"] = strconv.Itoa(parentID)
+	return api
+}
+
+// Query sets an arbitrary query argument, for filters registered by the
+// post type that have no dedicated builder method.
+func (api *ListResource[T]) Query(key, value string) *ListResource[T] {
+	api = api.Clone()
+	api.arguments[key] = value
+	return api
+}
+
+func (api *ListResource[T]) Do() (items []T, err error) {
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&items).
+		SetQueryParams(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return items, &wpError
+	}
+
+	return
+}
+
+type CreateResource[T any, D any] struct {
+	endpoint string
+	client   *RestClient
+	data     D
+}
+
+func (api *CreateResource[T, D]) Clone() *CreateResource[T, D] {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *CreateResource[T, D]) With(options ...RequestOption) *CreateResource[T, D] {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Resource[T, D]) Create(data D) *CreateResource[T, D] {
+	return &CreateResource[T, D]{
+		endpoint: api.endpoint,
+		client:   api.client,
+		data:     data,
+	}
+}
+
+func (api *CreateResource[T, D]) Do() (item T, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&item).
+		SetBody(api.data).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return item, &wpError
+	}
+
+	return
+}
+
+type RetrieveResource[T any] struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *RetrieveResource[T]) Clone() *RetrieveResource[T] {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveResource[T]) With(options ...RequestOption) *RetrieveResource[T] {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Resource[T, D]) Retrieve(id int) *RetrieveResource[T] {
+	return &RetrieveResource[T]{
+		endpoint:  api.endpoint + "/" + strconv.Itoa(id),
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *RetrieveResource[T]) ContextView() *RetrieveResource[T] {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *RetrieveResource[T]) ContextEdit() *RetrieveResource[T] {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *RetrieveResource[T]) ContextEmbed() *RetrieveResource[T] {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *RetrieveResource[T]) Password(password string) *RetrieveResource[T] {
+	api = api.Clone()
+	api.arguments["password"] = password
+	return api
+}
+
+func (api *RetrieveResource[T]) Do() (item *T, err error) {
+	endpoint := api.client.url(api.endpoint)
+
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&item).
+		SetQueryParams(api.arguments).
+		Get(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return item, &wpError
+	}
+
+	return
+}
+
+type UpdateResource[T any, D any] struct {
+	endpoint string
+	client   *RestClient
+	data     D
+}
+
+func (api *UpdateResource[T, D]) Clone() *UpdateResource[T, D] {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdateResource[T, D]) With(options ...RequestOption) *UpdateResource[T, D] {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Resource[T, D]) Update(id int, data D) *UpdateResource[T, D] {
+	return &UpdateResource[T, D]{
+		endpoint: api.endpoint + "/" + strconv.Itoa(id),
+		client:   api.client,
+		data:     data,
+	}
+}
+
+func (api *UpdateResource[T, D]) Do() (item T, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&item).
+		SetBody(api.data).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return item, &wpError
+	}
+
+	return
+}
+
+type DeleteResource[T any] struct {
+	endpoint string
+	client   *RestClient
+	force    bool
+}
+
+func (api *DeleteResource[T]) Clone() *DeleteResource[T] {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeleteResource[T]) With(options ...RequestOption) *DeleteResource[T] {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Resource[T, D]) Delete(id int) *DeleteResource[T] {
+	return &DeleteResource[T]{
+		endpoint: api.endpoint + "/" + strconv.Itoa(id),
+		client:   api.client,
+	}
+}
+
+func (api *DeleteResource[T]) Force() *DeleteResource[T] {
+	api = api.Clone()
+	api.force = true
+	return api
+}
+
+// Do deletes the item. Without Force the item is moved to the trash and
+// returned as is; with Force the API answers with the previous state, which
+// is unwrapped so both cases return the same model.
+func (api *DeleteResource[T]) Do() (item T, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("force", strconv.FormatBool(api.force)).
+		Delete(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return item, &wpError
+	}
+
+	if api.force {
+		var nested struct {
+			Deleted  bool `json:"deleted"`
+			Previous T    `json:"previous"`
+		}
+		err = unmarshal(resp.Bytes(), &nested)
+		if err != nil {
+			return
+		}
+		return nested.Previous, nil
+	}
+
+	err = unmarshal(resp.Bytes(), &item)
+	return
+}
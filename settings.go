@@ -1 +1,254 @@
-package gowprest
+package gowprest
+
+import (
+	"encoding/json"
+)
+
+// ShowOnFront selects what the front page of the site shows.
+type ShowOnFront string
+
+const (
+	// ShowOnFrontPosts shows the latest posts.
+	ShowOnFrontPosts ShowOnFront = "posts"
+	// ShowOnFrontPage shows the page set as PageOnFront.
+	ShowOnFrontPage ShowOnFront = "page"
+)
+
+// IsKnown reports whether s is posts or page.
+func (s ShowOnFront) IsKnown() bool {
+	return s == ShowOnFrontPosts || s == ShowOnFrontPage
+}
+
+// Settings are the site options exposed by /wp/v2/settings. Settings that
+// plugins register with show_in_rest are kept in Extras, so they can be
+// read with Extras.Decode.
+type Settings struct {
+	Title                string           `json:"title"`
+	Description          string           `json:"description"`
+	URL                  string           `json:"url"`
+	Email                string           `json:"email"`
+	Timezone             string           `json:"timezone"`
+	DateFormat           string           `json:"date_format"`
+	TimeFormat           string           `json:"time_format"`
+	StartOfWeek          int              `json:"start_of_week"`
+	Language             string           `json:"language"`
+	UseSmilies           bool             `json:"use_smilies"`
+	DefaultCategory      int              `json:"default_category"`
+	DefaultPostFormat    string           `json:"default_post_format"`
+	PostsPerPage         int              `json:"posts_per_page"`
+	ShowOnFront          ShowOnFront      `json:"show_on_front"`
+	PageOnFront          int              `json:"page_on_front"`
+	PageForPosts         int              `json:"page_for_posts"`
+	DefaultPingStatus    OpenClosedStatus `json:"default_ping_status"`
+	DefaultCommentStatus OpenClosedStatus `json:"default_comment_status"`
+	SiteLogo             int              `json:"site_logo"`
+	SiteIcon             int              `json:"site_icon"`
+
+	Extras Extras `json:"-"`
+}
+
+// SiteSettings reads and writes the site options. Both require the
+// manage_options capability, so requests are always authenticated.
+type SiteSettings struct {
+	client *RestClient
+}
+
+func (c *RestClient) Settings() *SiteSettings {
+	return &SiteSettings{client: c}
+}
+
+type RetrieveSettings struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *RetrieveSettings) Clone() *RetrieveSettings {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveSettings) With(options ...RequestOption) *RetrieveSettings {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *SiteSettings) Retrieve() *RetrieveSettings {
+	return &RetrieveSettings{
+		endpoint: "/wp/v2/settings",
+		client:   api.client,
+	}
+}
+
+func (api *RetrieveSettings) Do() (settings Settings, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&settings).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return settings, &wpError
+	}
+
+	return
+}
+
+// PatchSettings updates only the settings set on it, so options changed by
+// someone else in the meantime are not overwritten. Zero values are sent
+// as such.
+type PatchSettings struct {
+	endpoint string
+	client   *RestClient
+	fields   map[string]any
+}
+
+func (api *PatchSettings) Clone() *PatchSettings {
+	clone := *api
+	clone.fields = cloneFields(api.fields)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *PatchSettings) With(options ...RequestOption) *PatchSettings {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *SiteSettings) Patch() *PatchSettings {
+	return &PatchSettings{
+		endpoint: "/wp/v2/settings",
+		client:   api.client,
+		fields:   make(map[string]any),
+	}
+}
+
+// Set sends value for the setting as is, for settings registered by
+// plugins; a nil value is sent as null, which resets it to its default.
+func (api *PatchSettings) Set(name string, value any) *PatchSettings {
+	api = api.Clone()
+	api.fields[name] = value
+	return api
+}
+
+func (api *PatchSettings) Title(title string) *PatchSettings {
+	return api.Set("title", title)
+}
+
+func (api *PatchSettings) Description(description string) *PatchSettings {
+	return api.Set("description", description)
+}
+
+func (api *PatchSettings) URL(url string) *PatchSettings {
+	return api.Set("url", url)
+}
+
+func (api *PatchSettings) Email(email string) *PatchSettings {
+	return api.Set("email", email)
+}
+
+// Timezone sets the time zone by its tz database name, such as
+// "Europe/Paris".
+func (api *PatchSettings) Timezone(timezone string) *PatchSettings {
+	return api.Set("timezone", timezone)
+}
+
+// DateFormat sets the format of dates, in PHP date syntax such as "F j, Y".
+func (api *PatchSettings) DateFormat(format string) *PatchSettings {
+	return api.Set("date_format", format)
+}
+
+// TimeFormat sets the format of times, in PHP date syntax such as "g:i a".
+func (api *PatchSettings) TimeFormat(format string) *PatchSettings {
+	return api.Set("time_format", format)
+}
+
+// StartOfWeek sets the first day of the week, 0 being Sunday.
+func (api *PatchSettings) StartOfWeek(day int) *PatchSettings {
+	return api.Set("start_of_week", day)
+}
+
+// Language sets the site locale, such as "fr_FR".
+func (api *PatchSettings) Language(locale string) *PatchSettings {
+	return api.Set("language", locale)
+}
+
+func (api *PatchSettings) UseSmilies(useSmilies bool) *PatchSettings {
+	return api.Set("use_smilies", useSmilies)
+}
+
+func (api *PatchSettings) DefaultCategory(categoryID int) *PatchSettings {
+	return api.Set("default_category", categoryID)
+}
+
+func (api *PatchSettings) DefaultPostFormat(format string) *PatchSettings {
+	return api.Set("default_post_format", format)
+}
+
+func (api *PatchSettings) PostsPerPage(postsPerPage int) *PatchSettings {
+	return api.Set("posts_per_page", postsPerPage)
+}
+
+func (api *PatchSettings) ShowOnFront(show ShowOnFront) *PatchSettings {
+	return api.Set("show_on_front", show)
+}
+
+func (api *PatchSettings) PageOnFront(pageID int) *PatchSettings {
+	return api.Set("page_on_front", pageID)
+}
+
+func (api *PatchSettings) PageForPosts(pageID int) *PatchSettings {
+	return api.Set("page_for_posts", pageID)
+}
+
+func (api *PatchSettings) DefaultPingStatus(status OpenClosedStatus) *PatchSettings {
+	return api.Set("default_ping_status", status)
+}
+
+func (api *PatchSettings) DefaultCommentStatus(status OpenClosedStatus) *PatchSettings {
+	return api.Set("default_comment_status", status)
+}
+
+// SiteLogo sets the logo to an attachment; 0 removes it.
+func (api *PatchSettings) SiteLogo(mediaID int) *PatchSettings {
+	return api.Set("site_logo", mediaID)
+}
+
+// SiteIcon sets the icon to an attachment; 0 removes it.
+func (api *PatchSettings) SiteIcon(mediaID int) *PatchSettings {
+	return api.Set("site_icon", mediaID)
+}
+
+// Do sends the changed settings and returns all of them as stored.
+func (api *PatchSettings) Do() (settings Settings, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&settings).
+		SetBody(api.fields).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return settings, &wpError
+	}
+
+	return
+}
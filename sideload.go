@@ -0,0 +1,301 @@
+package gowprest
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrDownloadTooLarge is returned by SideloadMedia when the file is
+	// larger than its MaxSize.
+	ErrDownloadTooLarge = errors.New("gowprest: download exceeds the size limit")
+	// ErrDownloadType is returned by SideloadMedia when the file's content
+	// type is not one of its AllowTypes.
+	ErrDownloadType = errors.New("gowprest: download has a content type that is not allowed")
+)
+
+// defaultSideloadSize is the MaxSize of SideloadMedia unless set.
+const defaultSideloadSize = 20 << 20
+
+// sideloadExtensions are the extensions given to downloads whose name has
+// none, for the types WordPress accepts.
+var sideloadExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/avif":      ".avif",
+	"image/heic":      ".heic",
+	"application/pdf": ".pdf",
+}
+
+// SideloadMedia copies a remote file into the media library and optionally
+// makes it the featured image of a post or page.
+type SideloadMedia struct {
+	client       *RestClient
+	source       string
+	filename     string
+	maxSize      int64
+	allowedTypes []string
+	arguments    url.Values
+	processing   *ImageProcessing
+	deduplicate  bool
+	postID       int
+	pageID       int
+}
+
+func (api *SideloadMedia) Clone() *SideloadMedia {
+	clone := *api
+	clone.arguments = cloneValues(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only. The download
+// itself is sent without the client's headers and credentials.
+func (api *SideloadMedia) With(options ...RequestOption) *SideloadMedia {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Sideload downloads source and uploads it to the media library. By
+// default only images of up to 20 MiB are accepted.
+func (api *MediaLibrary) Sideload(source string) *SideloadMedia {
+	return &SideloadMedia{
+		client:       api.client,
+		source:       source,
+		maxSize:      defaultSideloadSize,
+		allowedTypes: []string{"image/*"},
+		arguments:    url.Values{},
+	}
+}
+
+// MaxSize limits the size of the download in bytes.
+func (api *SideloadMedia) MaxSize(size int64) *SideloadMedia {
+	api = api.Clone()
+	api.maxSize = size
+	return api
+}
+
+// AllowTypes replaces the accepted content types. A type ending in /*, such
+// as "image/*", accepts every subtype.
+func (api *SideloadMedia) AllowTypes(types ...string) *SideloadMedia {
+	api = api.Clone()
+	api.allowedTypes = types
+	return api
+}
+
+// Filename names the uploaded file. It defaults to the name sent in the
+// Content-Disposition of the download, then to the last segment of its URL,
+// with an extension matching its content type added when it has none.
+func (api *SideloadMedia) Filename(filename string) *SideloadMedia {
+	api = api.Clone()
+	api.filename = filename
+	return api
+}
+
+func (api *SideloadMedia) Title(title string) *SideloadMedia {
+	api = api.Clone()
+	api.arguments.Set("title", title)
+	return api
+}
+
+func (api *SideloadMedia) AltText(altText string) *SideloadMedia {
+	api = api.Clone()
+	api.arguments.Set("alt_text", altText)
+	return api
+}
+
+func (api *SideloadMedia) Caption(caption string) *SideloadMedia {
+	api = api.Clone()
+	api.arguments.Set("caption", caption)
+	return api
+}
+
+func (api *SideloadMedia) Description(description string) *SideloadMedia {
+	api = api.Clone()
+	api.arguments.Set("description", description)
+	return api
+}
+
+// Process prepares the image locally before it is uploaded. Duplicates are
+// still detected by the content of the download.
+func (api *SideloadMedia) Process(processing ImageProcessing) *SideloadMedia {
+	api = api.Clone()
+	api.processing = &processing
+	return api
+}
+
+// Deduplicate reuses an attachment with the same content instead of
+// uploading the file again. Attachments sideloaded this way have the
+// SHA-256 of their content as slug, which is how they are found; the
+// reused attachment keeps its own alt text and caption.
+func (api *SideloadMedia) Deduplicate() *SideloadMedia {
+	api = api.Clone()
+	api.deduplicate = true
+	return api
+}
+
+// ForPost attaches the upload to the post and makes it its featured image.
+func (api *SideloadMedia) ForPost(postID int) *SideloadMedia {
+	api = api.Clone()
+	api.postID, api.pageID = postID, 0
+	return api
+}
+
+// ForPage attaches the upload to the page and makes it its featured image.
+func (api *SideloadMedia) ForPage(pageID int) *SideloadMedia {
+	api = api.Clone()
+	api.postID, api.pageID = 0, pageID
+	return api
+}
+
+// Do downloads the file, uploads it unless a duplicate is found, and sets
+// the featured image. created is false when an existing attachment was
+// reused. The download is buffered in memory, within MaxSize, as its hash
+// is needed before uploading.
+func (api *SideloadMedia) Do() (media Media, created bool, err error) {
+	data, contentType, filename, err := api.download()
+	if err != nil {
+		return
+	}
+
+	slug := ""
+	if api.deduplicate {
+		sum := sha256.Sum256(data)
+		slug = "sha256-" + hex.EncodeToString(sum[:])
+
+		var existing []Media
+		existing, err = api.client.Media().List().
+			Slugs(slug).
+			StatusIn(StatusInherit, StatusPrivate).
+			Do()
+		if err != nil {
+			return
+		}
+		if len(existing) > 0 {
+			media = existing[0]
+		}
+	}
+
+	if media.ID == 0 {
+		upload := api.client.Media().Upload(bytes.NewReader(data), filename, contentType)
+		upload.arguments = cloneValues(api.arguments)
+		upload.processing = api.processing
+		if slug != "" {
+			upload = upload.Slug(slug)
+		}
+		if parent := cmp.Or(api.postID, api.pageID); parent != 0 {
+			upload = upload.Post(parent)
+		}
+
+		media, err = upload.Do()
+		if err != nil {
+			return
+		}
+		created = true
+	}
+
+	switch {
+	case api.postID != 0:
+		_, err = api.client.Posts().Patch(api.postID).FeaturedMedia(media.ID).Do()
+	case api.pageID != 0:
+		_, err = api.client.Pages().Patch(api.pageID).FeaturedMedia(media.ID).Do()
+	}
+	return
+}
+
+// download fetches the source within the size and type limits. It uses the
+// client's HTTP client, cassette, context and timeout but not its headers or
+// credentials, so none leak to the remote host.
+func (api *SideloadMedia) download() (data []byte, contentType, filename string, err error) {
+	ctx := api.client.context()
+	if api.client.cassette != nil {
+		ctx = context.WithValue(ctx, cassetteKey{}, api.client.cassette)
+	}
+
+	req := api.client.httpClient.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true)
+	if api.client.timeout > 0 {
+		req.SetTimeout(api.client.timeout)
+	}
+
+	resp, err := req.Get(api.source)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		err = fmt.Errorf("gowprest: download %s: %s", api.source, resp.Status())
+		return
+	}
+
+	contentType, _, _ = mime.ParseMediaType(resp.Header().Get("Content-Type"))
+	if !api.allowed(contentType) {
+		err = fmt.Errorf("%w: %q", ErrDownloadType, contentType)
+		return
+	}
+
+	if resp.RawResponse.ContentLength > api.maxSize {
+		err = ErrDownloadTooLarge
+		return
+	}
+
+	data, err = io.ReadAll(io.LimitReader(resp.Body, api.maxSize+1))
+	if err != nil {
+		return
+	}
+	if int64(len(data)) > api.maxSize {
+		err = ErrDownloadTooLarge
+		return
+	}
+
+	filename = api.filename
+	if filename == "" {
+		if _, params, err := mime.ParseMediaType(resp.Header().Get("Content-Disposition")); err == nil {
+			filename = path.Base(params["filename"])
+		}
+	}
+	if filename == "" || filename == "." || filename == "/" {
+		filename = "download"
+		if source, err := url.Parse(api.source); err == nil && path.Base(source.Path) != "/" && path.Base(source.Path) != "." {
+			filename = path.Base(source.Path)
+		}
+	}
+	if path.Ext(filename) == "" {
+		extension := sideloadExtensions[contentType]
+		if extension == "" {
+			if extensions, _ := mime.ExtensionsByType(contentType); len(extensions) > 0 {
+				extension = extensions[0]
+			}
+		}
+		filename += extension
+	}
+
+	return
+}
+
+func (api *SideloadMedia) allowed(contentType string) bool {
+	for _, allowed := range api.allowedTypes {
+		if prefix, isWildcard := strings.CutSuffix(allowed, "/*"); isWildcard {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+		} else if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -1 +1,565 @@
-package gowprest
+package gowprest
+
+import (
+	"encoding/json"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+type Tag struct {
+	ID          int    `json:"id,omitempty"`
+	Count       int    `json:"count,omitempty"`
+	Description string `json:"description,omitempty"`
+	Link        string `json:"link,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	Taxonomy    string `json:"taxonomy,omitempty"`
+	Meta        any    `json:"meta,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type TagData struct {
+	ID          int    `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	Meta        any    `json:"meta,omitempty"`
+}
+
+// Tags manages the post_tag taxonomy, the flat terms referenced by
+// Post.Tags.
+type Tags struct {
+	client *RestClient
+}
+
+func (c *RestClient) Tags() *Tags {
+	return &Tags{client: c}
+}
+
+type ListTags struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *ListTags) Clone() *ListTags {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListTags) With(options ...RequestOption) *ListTags {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Tags) List() *ListTags {
+	return &ListTags{
+		endpoint:  "/wp/v2/tags",
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *ListTags) ContextView() *ListTags {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *ListTags) ContextEdit() *ListTags {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *ListTags) ContextEmbed() *ListTags {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *ListTags) Page(page int) *ListTags {
+	api = api.Clone()
+	api.arguments["page"] = strconv.Itoa(page)
+	return api
+}
+
+func (api *ListTags) PerPage(perPage int) *ListTags {
+	api = api.Clone()
+	api.arguments["per_page"] = strconv.Itoa(perPage)
+	return api
+}
+
+func (api *ListTags) Search(query string) *ListTags {
+	api = api.Clone()
+	api.arguments["search"] = query
+	return api
+}
+
+func (api *ListTags) Exclude(excludeIDs ...int) *ListTags {
+	api = api.Clone()
+	excludes := []string{}
+	for _, excludeId := range excludeIDs {
+		excludes = append(excludes, strconv.Itoa(excludeId))
+	}
+	api.arguments["exclude"] = strings.Join(excludes, ",")
+	return api
+}
+
+func (api *ListTags) Include(includeIDs ...int) *ListTags {
+	api = api.Clone()
+	includes := []string{}
+	for _, includeId := range includeIDs {
+		includes = append(includes, strconv.Itoa(includeId))
+	}
+	api.arguments["include"] = strings.Join(includes, ",")
+	return api
+}
+
+func (api *ListTags) OrderAsc() *ListTags {
+	api = api.Clone()
+	api.arguments["order"] = "asc"
+	return api
+}
+
+func (api *ListTags) OrderDesc() *ListTags {
+	api = api.Clone()
+	api.arguments["order"] = "desc"
+	return api
+}
+
+func (api *ListTags) OrderById() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "id"
+	return api
+}
+
+func (api *ListTags) OrderByInclude() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "include"
+	return api
+}
+
+func (api *ListTags) OrderByName() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "name"
+	return api
+}
+
+func (api *ListTags) OrderBySlug() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "slug"
+	return api
+}
+
+func (api *ListTags) OrderByIncludeSlug() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "include_slugs"
+	return api
+}
+
+func (api *ListTags) OrderByTermGroup() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "term_group"
+	return api
+}
+
+func (api *ListTags) OrderByDescription() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "description"
+	return api
+}
+
+func (api *ListTags) OrderByCount() *ListTags {
+	api = api.Clone()
+	api.arguments["orderby"] = "count"
+	return api
+}
+
+func (api *ListTags) HideEmpty(hide bool) *ListTags {
+	api = api.Clone()
+	api.arguments["hide_empty"] = strconv.FormatBool(hide)
+	return api
+}
+
+func (api *ListTags) Post(postID int) *ListTags {
+	api = api.Clone()
+	api.arguments["post"] = strconv.Itoa(postID)
+	return api
+}
+
+func (api *ListTags) Slug(slugs ...string) *ListTags {
+	api = api.Clone()
+	api.arguments["slug"] = strings.Join(slugs, ",")
+	return api
+}
+
+func (api *ListTags) Do() (tags []Tag, err error) {
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&tags).
+		SetQueryParams(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return tags, &wpError
+	}
+
+	return
+}
+
+type CreateTag struct {
+	endpoint string
+	client   *RestClient
+	tag      TagData
+}
+
+func (api *CreateTag) Clone() *CreateTag {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *CreateTag) With(options ...RequestOption) *CreateTag {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Tags) Create(tag TagData) *CreateTag {
+	return &CreateTag{
+		endpoint: "/wp/v2/tags",
+		client:   api.client,
+		tag:      tag,
+	}
+}
+
+func (api *CreateTag) Do() (tag Tag, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&tag).
+		SetBody(api.tag).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return tag, &wpError
+	}
+
+	return
+}
+
+type RetrieveTag struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *RetrieveTag) Clone() *RetrieveTag {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveTag) With(options ...RequestOption) *RetrieveTag {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Tags) Retrieve(tagID int) *RetrieveTag {
+	return &RetrieveTag{
+		endpoint:  "/wp/v2/tags/" + strconv.Itoa(tagID),
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *RetrieveTag) ContextView() *RetrieveTag {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *RetrieveTag) ContextEdit() *RetrieveTag {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *RetrieveTag) ContextEmbed() *RetrieveTag {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *RetrieveTag) Do() (tag *Tag, err error) {
+	endpoint := api.client.url(api.endpoint)
+
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&tag).
+		SetQueryParams(api.arguments).
+		Get(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return tag, &wpError
+	}
+
+	return
+}
+
+type UpdateTag struct {
+	endpoint string
+	client   *RestClient
+	tag      TagData
+}
+
+func (api *UpdateTag) Clone() *UpdateTag {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdateTag) With(options ...RequestOption) *UpdateTag {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Tags) Update(tag TagData) *UpdateTag {
+	return &UpdateTag{
+		endpoint: "/wp/v2/tags/" + strconv.Itoa(tag.ID),
+		client:   api.client,
+		tag:      tag,
+	}
+}
+
+func (api *UpdateTag) Do() (tag Tag, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&tag).
+		SetBody(api.tag).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return tag, &wpError
+	}
+
+	return
+}
+
+// PatchTag updates only the fields set on it, so the description can be
+// emptied.
+type PatchTag struct {
+	endpoint string
+	client   *RestClient
+	fields   map[string]any
+}
+
+func (api *PatchTag) Clone() *PatchTag {
+	clone := *api
+	clone.fields = cloneFields(api.fields)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *PatchTag) With(options ...RequestOption) *PatchTag {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Tags) Patch(tagID int) *PatchTag {
+	return &PatchTag{
+		endpoint: "/wp/v2/tags/" + strconv.Itoa(tagID),
+		client:   api.client,
+		fields:   make(map[string]any),
+	}
+}
+
+// Set sends value for the field as is; a nil value is sent as null.
+func (api *PatchTag) Set(field string, value any) *PatchTag {
+	api = api.Clone()
+	api.fields[field] = value
+	return api
+}
+
+func (api *PatchTag) Name(name string) *PatchTag {
+	return api.Set("name", name)
+}
+
+func (api *PatchTag) Slug(slug string) *PatchTag {
+	return api.Set("slug", slug)
+}
+
+func (api *PatchTag) Description(description string) *PatchTag {
+	return api.Set("description", description)
+}
+
+// Meta sets a single meta key; nil deletes it.
+func (api *PatchTag) Meta(key string, value any) *PatchTag {
+	api = api.Clone()
+	meta, _ := api.fields["meta"].(map[string]any)
+	if meta == nil {
+		meta = make(map[string]any)
+		api.fields["meta"] = meta
+	}
+	meta[key] = value
+	return api
+}
+
+func (api *PatchTag) Do() (tag Tag, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&tag).
+		SetBody(api.fields).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return tag, &wpError
+	}
+
+	return
+}
+
+type DeleteTag struct {
+	endpoint string
+	client   *RestClient
+	tagID    int
+	force    bool
+}
+
+func (api *DeleteTag) Clone() *DeleteTag {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeleteTag) With(options ...RequestOption) *DeleteTag {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Delete removes the tag. Tags cannot be trashed, so WordPress rejects the
+// request with rest_trash_not_supported unless Force is set.
+func (api *Tags) Delete(tagID int) *DeleteTag {
+	return &DeleteTag{
+		endpoint: "/wp/v2/tags",
+		client:   api.client,
+		tagID:    tagID,
+	}
+}
+
+func (api *DeleteTag) Force() *DeleteTag {
+	api = api.Clone()
+	api.force = true
+	return api
+}
+
+func (api *DeleteTag) Do() (tag Tag, err error) {
+	endpoint := api.client.url(api.endpoint + "/" + strconv.Itoa(api.tagID))
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("force", strconv.FormatBool(api.force)).
+		Delete(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return tag, &wpError
+	}
+
+	if api.force {
+		var nested struct {
+			Deleted  bool `json:"deleted"`
+			Previous Tag  `json:"previous"`
+		}
+		err = unmarshal(resp.Bytes(), &nested)
+		if err != nil {
+			return
+		}
+		return nested.Previous, nil
+	}
+
+	err = unmarshal(resp.Bytes(), &tag)
+	return
+}
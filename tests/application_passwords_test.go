@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Rotation revokes the credentials it runs with, so these tests use a
+// server of their own.
+func TestApplicationPasswordsAPI(t *testing.T) {
+	server := wptest.NewServer()
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	passwords := client.Users().MyApplicationPasswords()
+
+	// 1. Introspect the password in use
+	current, err := passwords.Introspect().Do()
+	require.NoError(t, err)
+	assert.NotEmpty(t, current.UUID)
+	assert.Empty(t, current.Password)
+	require.NotNil(t, current.LastUsed)
+
+	// 2. Create, rename and revoke
+	created, err := passwords.Create(gowprest.ApplicationPasswordData{
+		Name:  "Deploy script",
+		AppID: "8d6bd8c8-5c4e-4d4c-9a59-7e3d0d4c2f11",
+	}).Do()
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Password)
+	assert.Nil(t, created.LastUsed)
+
+	_, err = passwords.Create(gowprest.ApplicationPasswordData{Name: "Deploy script"}).Do()
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "application_password_duplicate_name", wpError.Code)
+
+	me, err := client.Users().Me().Do()
+	require.NoError(t, err)
+	listed, err := client.Users().ApplicationPasswords(me.ID).List().Do()
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+
+	renamed, err := passwords.Update(created.UUID, gowprest.ApplicationPasswordData{Name: "CI"}).Do()
+	require.NoError(t, err)
+	assert.Equal(t, "CI", renamed.Name)
+
+	deleted, err := passwords.Delete(created.UUID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, created.UUID, deleted.UUID)
+
+	_, err = passwords.Retrieve(created.UUID).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_application_password_not_found", wpError.Code)
+
+	// 3. Rotate
+	rotated, password, err := client.Users().RotateApplicationPassword().Do()
+	require.NoError(t, err)
+	assert.NotEqual(t, current.UUID, password.UUID)
+	assert.Equal(t, current.Name, password.Name)
+	assert.NotEmpty(t, password.Password)
+
+	introspected, err := rotated.Users().MyApplicationPasswords().Introspect().Do()
+	require.NoError(t, err)
+	assert.Equal(t, password.UUID, introspected.UUID)
+
+	_, err = client.Users().Me().Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "incorrect_password", wpError.Code, "the old password is revoked")
+}
@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "posts.json")
+
+	server := wptest.NewServer()
+	recorder := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password).
+		WithCassette(path, gowprest.CassetteRecord)
+
+	created, err := recorder.Posts().Create(gowprest.PostData{Title: "Recorded", Password: "secret"}).Do()
+	require.NoError(t, err)
+
+	recorded, err := recorder.Posts().List().Search("hello").Do()
+	require.NoError(t, err)
+
+	recorder.Close()
+	server.Close()
+
+	t.Run("credentials are redacted", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(data), "Basic ")
+		assert.NotContains(t, string(data), "secret")
+		assert.Contains(t, string(data), "[REDACTED]")
+	})
+
+	// The server is gone: every answer now comes from the cassette.
+	player := gowprest.NewClient("http://replay.invalid").
+		WithBasicAuth(wptest.Username, wptest.Password).
+		WithCassette(path, gowprest.CassetteReplay)
+	defer player.Close()
+
+	t.Run("replays recorded interactions", func(t *testing.T) {
+		post, err := player.Posts().Create(gowprest.PostData{Title: "Recorded", Password: "secret"}).Do()
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, post.ID)
+
+		posts, err := player.Posts().List().Search("hello").Do()
+		require.NoError(t, err)
+		assert.Equal(t, recorded, posts)
+	})
+
+	t.Run("unmatched request fails", func(t *testing.T) {
+		_, err := player.Posts().Retrieve(created.ID).Do()
+
+		assert.ErrorIs(t, err, gowprest.ErrCassetteMiss)
+	})
+
+	t.Run("missing cassette fails", func(t *testing.T) {
+		missing := gowprest.NewClient("http://replay.invalid").
+			WithCassette(filepath.Join(t.TempDir(), "missing.json"), gowprest.CassetteReplay)
+		defer missing.Close()
+
+		_, err := missing.Discover()
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestCassetteUpload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.json")
+	data := pngImage(t, 64, 64)
+
+	server := wptest.NewServer()
+	recorder := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password).
+		WithCassette(path, gowprest.CassetteRecord)
+
+	uploaded, err := recorder.Media().
+		Upload(bytes.NewReader(data), "pixel.png", "image/png").
+		Do()
+	require.NoError(t, err)
+
+	recorder.Close()
+	server.Close()
+
+	// The streamed upload is recorded by its size, not its bytes.
+	file, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var cassette gowprest.Cassette
+	require.NoError(t, json.Unmarshal(file, &cassette))
+	require.Len(t, cassette.Interactions, 1)
+	request := cassette.Interactions[0].Request
+	assert.Empty(t, request.Body)
+	assert.Equal(t, int64(len(data)), request.BodySize)
+
+	player := gowprest.NewClient("http://replay.invalid").
+		WithBasicAuth(wptest.Username, wptest.Password).
+		WithCassette(path, gowprest.CassetteReplay)
+	defer player.Close()
+
+	var sent int64
+	replayed, err := player.Media().
+		Upload(bytes.NewReader(data), "pixel.png", "image/png").
+		Progress(func(s, _ int64) { sent = s }).
+		Do()
+	require.NoError(t, err)
+	assert.Equal(t, uploaded.ID, replayed.ID)
+	assert.Equal(t, int64(len(data)), sent)
+}
+
+func TestCassetteBinaryResponse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sideload.json")
+	photo := pngImage(t, 32, 32)
+
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(photo)
+	}))
+	source := remote.URL + "/images/pixel.png"
+
+	server := wptest.NewServer()
+	recorder := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password).
+		WithCassette(path, gowprest.CassetteRecord)
+
+	recorded, _, err := recorder.Media().Sideload(source).Deduplicate().Do()
+	require.NoError(t, err)
+
+	recorder.Close()
+	server.Close()
+	remote.Close()
+
+	file, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var cassette gowprest.Cassette
+	require.NoError(t, json.Unmarshal(file, &cassette))
+	require.NotEmpty(t, cassette.Interactions)
+	download := cassette.Interactions[0].Response
+	assert.Empty(t, download.Body)
+	assert.Equal(t, photo, download.BodyBase64)
+
+	// Deduplicate looks the media up by a hash of the downloaded bytes, so
+	// the replay only matches if the image comes back unchanged.
+	player := gowprest.NewClient("http://replay.invalid").
+		WithBasicAuth(wptest.Username, wptest.Password).
+		WithCassette(path, gowprest.CassetteReplay)
+	defer player.Close()
+
+	replayed, _, err := player.Media().Sideload(source).Deduplicate().Do()
+	require.NoError(t, err)
+	assert.Equal(t, recorded.ID, replayed.ID)
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCompatServer answers like a WordPress site behind a firewall that
+// rejects DELETE and strips the Authorization header.
+func newCompatServer(t *testing.T, received *[]*http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = append(*received, r)
+
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body any = map[string]any{"id": 7, "status": "trash"}
+		if r.URL.Query().Get("force") == "true" {
+			body = map[string]any{
+				"deleted":  true,
+				"previous": map[string]any{"id": 7, "post": 3},
+			}
+		}
+
+		if r.URL.Query().Has("_envelope") {
+			body = map[string]any{
+				"body":    body,
+				"status":  200,
+				"headers": map[string]any{"X-WP-Total": 1},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+}
+
+func TestCompatibilityMethodOverride(t *testing.T) {
+	var received []*http.Request
+	server := newCompatServer(t, &received)
+	defer server.Close()
+
+	t.Run("query", func(t *testing.T) {
+		received = nil
+		client := gowprest.NewClient(server.URL).
+			WithBasicAuth("admin", "secret").
+			WithCompatibility(gowprest.Compatibility{
+				MethodOverride: gowprest.MethodOverrideQuery,
+			})
+		defer client.Close()
+
+		post, err := client.Posts().Delete(7).Do()
+		require.NoError(t, err)
+		assert.Equal(t, 7, post.ID)
+
+		require.Len(t, received, 1)
+		assert.Equal(t, http.MethodPost, received[0].Method)
+		assert.Equal(t, "DELETE", received[0].URL.Query().Get("_method"))
+		assert.Empty(t, received[0].Header.Get("X-HTTP-Method-Override"))
+	})
+
+	t.Run("header", func(t *testing.T) {
+		received = nil
+		client := gowprest.NewClient(server.URL).
+			WithBasicAuth("admin", "secret").
+			WithCompatibility(gowprest.Compatibility{
+				MethodOverride: gowprest.MethodOverrideHeader,
+			})
+		defer client.Close()
+
+		deleted, err := client.Comments().Delete(7).Force().Do()
+		require.NoError(t, err)
+		assert.True(t, deleted.Deleted)
+		assert.Equal(t, 7, deleted.Previous.ID)
+
+		require.Len(t, received, 1)
+		assert.Equal(t, http.MethodPost, received[0].Method)
+		assert.Equal(t, "DELETE", received[0].Header.Get("X-HTTP-Method-Override"))
+	})
+}
+
+func TestCompatibilityNonceAndEnvelope(t *testing.T) {
+	var received []*http.Request
+	server := newCompatServer(t, &received)
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL).
+		WithAuthenticator(gowprest.CookieAuthentication{
+			Nonce:   "abc123",
+			Cookies: []*http.Cookie{{Name: "wordpress_logged_in", Value: "session"}},
+		}).
+		WithCompatibility(gowprest.Compatibility{
+			MethodOverride: gowprest.MethodOverrideQuery,
+			NonceInQuery:   true,
+			Envelope:       true,
+		})
+	defer client.Close()
+
+	deleted, err := client.Comments().Delete(7).Force().Do()
+	require.NoError(t, err)
+	assert.Equal(t, 7, deleted.Previous.ID)
+	assert.Equal(t, 3, deleted.Previous.Post)
+
+	require.Len(t, received, 1)
+	query := received[0].URL.Query()
+	assert.Equal(t, "abc123", query.Get("_wpnonce"))
+	assert.Equal(t, "1", query.Get("_envelope"))
+	assert.Empty(t, received[0].Header.Get("X-WP-Nonce"))
+
+	cookie, err := received[0].Cookie("wordpress_logged_in")
+	require.NoError(t, err)
+	assert.Equal(t, "session", cookie.Value)
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimisticConcurrency(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	post, err := client.Posts().Create(gowprest.PostData{Title: faker.Sentence()}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(post.ID).Force().Do()
+
+	modified := post.ModifiedGMT.Resolve(time.UTC)
+
+	t.Run("unchanged post is written", func(t *testing.T) {
+		updated, err := client.Posts().
+			Update(gowprest.PostData{ID: post.ID, Title: "First editor"}).
+			IfUnmodifiedSince(modified).
+			Do()
+
+		require.NoError(t, err)
+		assert.Equal(t, "First editor", updated.Title.Rendered)
+	})
+
+	t.Run("stale copy is rejected", func(t *testing.T) {
+		stale := modified.Add(-time.Hour)
+
+		_, err := client.Posts().
+			Update(gowprest.PostData{ID: post.ID, Title: "Second editor"}).
+			IfUnmodifiedSince(stale).
+			Do()
+
+		require.ErrorIs(t, err, gowprest.ErrConflict)
+
+		var conflict *gowprest.ConflictError[gowprest.Post, gowprest.PostData]
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, "First editor", conflict.Current.Title.Rendered)
+		assert.Equal(t, "Second editor", conflict.Attempted.Title)
+
+		current, err := client.Posts().Retrieve(post.ID).ContextEdit().Do()
+		require.NoError(t, err)
+		assert.Equal(t, "First editor", current.Title.Rendered, "nothing is written on conflict")
+	})
+
+	t.Run("patch is guarded too", func(t *testing.T) {
+		_, err := client.Posts().Patch(post.ID).
+			Excerpt("").
+			IfUnmodifiedSince(modified.Add(-time.Hour)).
+			Do()
+
+		var conflict *gowprest.ConflictError[gowprest.Post, map[string]any]
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, "", conflict.Attempted["excerpt"])
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		page, err := client.Pages().Create(gowprest.PageData{Title: faker.Sentence()}).Do()
+		require.NoError(t, err)
+		defer client.Pages().Delete(page.ID).Force().Do()
+
+		_, err = client.Pages().
+			Update(gowprest.PageData{ID: page.ID, Title: "Stale"}).
+			IfUnmodifiedSince(page.ModifiedGMT.Resolve(time.UTC).Add(-time.Minute)).
+			Do()
+
+		assert.ErrorIs(t, err, gowprest.ErrConflict)
+	})
+}
+
+func TestOptimisticConcurrencyUnknown(t *testing.T) {
+	current := `{"id": 1, "title": {"raw": "Stripped"}}`
+	writes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			writes++
+		}
+		w.Write([]byte(current))
+	}))
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL)
+	defer client.Close()
+
+	t.Run("missing modified_gmt", func(t *testing.T) {
+		_, err := client.Posts().
+			Update(gowprest.PostData{ID: 1, Title: "Unchecked"}).
+			IfUnmodifiedSince(time.Now()).
+			Do()
+		require.ErrorIs(t, err, gowprest.ErrModifiedUnknown)
+
+		_, err = client.Pages().Patch(1).Title("Unchecked").IfUnmodifiedSince(time.Now()).Do()
+		require.ErrorIs(t, err, gowprest.ErrModifiedUnknown)
+	})
+
+	t.Run("null body", func(t *testing.T) {
+		current = `null`
+
+		_, err := client.Posts().Patch(1).Title("Unchecked").IfUnmodifiedSince(time.Now()).Do()
+		require.ErrorIs(t, err, gowprest.ErrModifiedUnknown)
+
+		_, err = client.Pages().
+			Update(gowprest.PageData{ID: 1, Title: "Unchecked"}).
+			IfUnmodifiedSince(time.Now()).
+			Do()
+		require.ErrorIs(t, err, gowprest.ErrModifiedUnknown)
+	})
+
+	assert.Zero(t, writes, "nothing is written without a check")
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate(t *testing.T) {
+	t.Run("decodes null and empty as unset", func(t *testing.T) {
+		var fields struct {
+			Date    gowprest.Date  `json:"date"`
+			DateGMT *gowprest.Date `json:"date_gmt"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(`{"date":"","date_gmt":null}`), &fields))
+		assert.True(t, fields.Date.IsZero())
+		assert.Nil(t, fields.DateGMT)
+	})
+
+	t.Run("round-trips wordpress formats", func(t *testing.T) {
+		for input, output := range map[string]string{
+			`"2024-03-01T10:30:00"`:       `"2024-03-01T10:30:00"`,
+			`"2024-03-01 10:30:00"`:       `"2024-03-01T10:30:00"`,
+			`"2024-03-01T10:30:00+07:00"`: `"2024-03-01T10:30:00+07:00"`,
+			`"2024-03-01T10:30:00Z"`:      `"2024-03-01T10:30:00Z"`,
+		} {
+			var date gowprest.Date
+			require.NoError(t, json.Unmarshal([]byte(input), &date), input)
+
+			data, err := json.Marshal(date)
+			require.NoError(t, err)
+			assert.Equal(t, output, string(data))
+		}
+	})
+
+	t.Run("rejects other formats", func(t *testing.T) {
+		var date gowprest.Date
+		assert.Error(t, json.Unmarshal([]byte(`"01/03/2024"`), &date))
+	})
+
+	t.Run("resolves wall clock in a location", func(t *testing.T) {
+		var date gowprest.Date
+		require.NoError(t, json.Unmarshal([]byte(`"2024-03-01T10:30:00"`), &date))
+
+		jakarta := time.FixedZone("WIB", 7*3600)
+		assert.False(t, date.HasOffset())
+		assert.True(t, date.Resolve(jakarta).Equal(time.Date(2024, 3, 1, 3, 30, 0, 0, time.UTC)))
+	})
+
+	t.Run("time as decoded", func(t *testing.T) {
+		var date gowprest.Date
+		require.NoError(t, json.Unmarshal([]byte(`"2024-03-01T10:30:00"`), &date))
+
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC), date.Time())
+		assert.Equal(t, date.Resolve(time.UTC), date.Time())
+	})
+
+	t.Run("keeps an offset when resolved", func(t *testing.T) {
+		var date gowprest.Date
+		require.NoError(t, json.Unmarshal([]byte(`"2024-03-01T10:30:00+07:00"`), &date))
+
+		assert.True(t, date.HasOffset())
+		assert.True(t, date.Resolve(time.UTC).Equal(time.Date(2024, 3, 1, 3, 30, 0, 0, time.UTC)))
+	})
+}
+
+func TestScheduleAcrossTimezones(t *testing.T) {
+	server := wptest.NewServer()
+	server.Location = time.FixedZone("", 5*3600+1800)
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	info, err := client.Discover()
+	require.NoError(t, err)
+
+	loc := info.Location()
+	_, offset := time.Now().In(loc).Zone()
+	assert.Equal(t, 5*3600+1800, offset)
+
+	t.Run("instant", func(t *testing.T) {
+		publishAt := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+
+		post, err := client.Posts().Create(gowprest.PostData{
+			Title:  "Scheduled",
+			Status: gowprest.StatusPublished,
+			Date:   gowprest.NewDate(publishAt),
+		}).Do()
+		require.NoError(t, err)
+
+		assert.Equal(t, gowprest.StatusFuture, post.Status)
+		assert.True(t, post.Date.Resolve(loc).Equal(publishAt))
+		assert.True(t, post.DateGMT.Resolve(time.UTC).Equal(publishAt))
+	})
+
+	t.Run("site wall clock", func(t *testing.T) {
+		wall := time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC)
+
+		post, err := client.Posts().Create(gowprest.PostData{
+			Title:  "Morning post",
+			Status: gowprest.StatusPublished,
+			Date:   gowprest.LocalDate(wall),
+		}).Do()
+		require.NoError(t, err)
+
+		assert.Equal(t, "2020-01-02T09:00:00", post.Date.String())
+		assert.Equal(t, "2020-01-02T03:30:00", post.DateGMT.String())
+		assert.True(t, post.Date.Resolve(loc).Equal(post.DateGMT.Resolve(time.UTC)))
+	})
+
+	t.Run("date filters", func(t *testing.T) {
+		posts, err := client.Posts().List().
+			After(time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)).
+			Before(time.Date(2020, 1, 2, 4, 0, 0, 0, time.UTC)).
+			Do()
+		require.NoError(t, err)
+
+		require.Len(t, posts, 1)
+		assert.Equal(t, "Morning post", posts[0].Title.Rendered)
+	})
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmutableBuilders(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	post, err := client.Posts().Create(gowprest.PostData{
+		Title:  "Immutable builders",
+		Status: gowprest.StatusPublished,
+	}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(post.ID).Force().Do()
+
+	base := client.Posts().List().Include(post.ID)
+
+	t.Run("chained calls leave the receiver untouched", func(t *testing.T) {
+		excluding := base.Exclude(post.ID)
+
+		posts, err := excluding.Do()
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+
+		posts, err = base.Do()
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, post.ID, posts[0].ID)
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		clone := base.Clone()
+		assert.NotSame(t, base, clone)
+
+		posts, err := clone.Do()
+		require.NoError(t, err)
+		assert.Len(t, posts, 1)
+	})
+
+	t.Run("patch meta", func(t *testing.T) {
+		patch := client.Posts().Patch(post.ID).Meta("first", "1")
+		_ = patch.Meta("second", "2")
+
+		patched, err := patch.Do()
+		require.NoError(t, err)
+		assert.Equal(t, "1", patched.Meta["first"])
+		assert.NotContains(t, patched.Meta, "second")
+	})
+
+	t.Run("shared across goroutines", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				list := base.PerPage(i + 1)
+				if i%2 == 0 {
+					list = list.Exclude(post.ID)
+				}
+
+				posts, err := list.Do()
+				assert.NoError(t, err)
+				if i%2 == 0 {
+					assert.Empty(t, posts)
+				} else {
+					assert.Len(t, posts, 1)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestDerivedClients(t *testing.T) {
+	base := gowprest.NewClient(blogUrl)
+	defer base.Close()
+
+	admin := base.WithBasicAuth(
+		os.Getenv("BLOG_USERNAME"),
+		os.Getenv("BLOG_APP_PASSWORD"),
+	)
+	intruder := base.WithBasicAuth(os.Getenv("BLOG_USERNAME"), "wrong password")
+
+	t.Run("credentials stay with the derived client", func(t *testing.T) {
+		_, err := base.Posts().List().StatusDraft().Do()
+		assert.Error(t, err, "the base client has no credentials")
+
+		_, err = admin.Posts().List().StatusDraft().Do()
+		assert.NoError(t, err)
+	})
+
+	t.Run("concurrent tenants", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				client := admin
+				if i%2 == 0 {
+					client = intruder
+				}
+
+				_, err := client.Posts().List().StatusDraft().Do()
+				if i%2 == 0 {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("cassette stays with the derived client", func(t *testing.T) {
+		replay := admin.WithCassette(filepath.Join(t.TempDir(), "missing.json"), gowprest.CassetteReplay)
+
+		_, err := replay.Posts().List().Do()
+		assert.Error(t, err)
+
+		_, err = admin.Posts().List().Do()
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditContext(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	source := "<!-- wp:paragraph -->\n<p>Stored as written</p>\n<!-- /wp:paragraph -->"
+
+	post, err := client.Posts().Create(gowprest.PostData{
+		Title:    "Raw title",
+		Content:  source,
+		Excerpt:  "Raw excerpt",
+		Password: "letmein",
+	}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(post.ID).Force().Do()
+
+	t.Run("raw fields", func(t *testing.T) {
+		edit, err := client.Posts().Retrieve(post.ID).ContextEdit().Do()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Raw title", edit.Title.Raw)
+		assert.Equal(t, source, edit.Content.Raw)
+		assert.Equal(t, 1, edit.Content.BlockVersion)
+		assert.Equal(t, "Raw excerpt", edit.Excerpt.Raw)
+		assert.Equal(t, "letmein", edit.Password)
+		assert.NotEmpty(t, edit.PermalinkTemplate)
+		assert.NotEmpty(t, edit.GeneratedSlug)
+	})
+
+	t.Run("round trip keeps the source", func(t *testing.T) {
+		edit, err := client.Posts().Retrieve(post.ID).ContextEdit().Do()
+		require.NoError(t, err)
+
+		data := edit.Data()
+		data.Title = "Edited title"
+
+		_, err = client.Posts().Update(data).Do()
+		require.NoError(t, err)
+
+		updated, err := client.Posts().Retrieve(post.ID).ContextEdit().Do()
+		require.NoError(t, err)
+		assert.Equal(t, "Edited title", updated.Title.Raw)
+		assert.Equal(t, source, updated.Content.Raw)
+		assert.Equal(t, "Raw excerpt", updated.Excerpt.Raw)
+		assert.Nil(t, updated.DateGMT, "a draft keeps its floating date")
+	})
+
+	t.Run("revisions", func(t *testing.T) {
+		revisions, err := client.Posts().Revisions(post.ID).List().ContextEdit().Do()
+		require.NoError(t, err)
+		require.NotEmpty(t, revisions)
+
+		assert.Equal(t, source, revisions[0].Content.Raw)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		published, err := client.Posts().Create(gowprest.PostData{
+			Title:  "Commented",
+			Status: gowprest.StatusPublished,
+		}).Do()
+		require.NoError(t, err)
+		defer client.Posts().Delete(published.ID).Force().Do()
+
+		comment, err := client.Comments().Create(gowprest.CommentData{
+			Post:    published.ID,
+			Content: "Raw *comment*",
+		}).Do()
+		require.NoError(t, err)
+		defer client.Comments().Delete(comment.ID).Force().Do()
+
+		edit, err := client.Comments().Retrieve(comment.ID).ContextEdit().Do()
+		require.NoError(t, err)
+		assert.Equal(t, "Raw *comment*", edit.Content.Raw)
+		assert.NotEmpty(t, edit.AuthorEmail)
+
+		data := edit.Data()
+		assert.Equal(t, "Raw *comment*", data.Content)
+		assert.Equal(t, edit.AuthorEmail, data.AuthorEmail)
+	})
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTolerantEnums(t *testing.T) {
+	t.Run("core values", func(t *testing.T) {
+		for _, status := range []gowprest.PostStatus{
+			gowprest.StatusFuture, gowprest.StatusTrash,
+			gowprest.StatusAutoDraft, gowprest.StatusInherit,
+		} {
+			var post gowprest.Post
+			require.NoError(t, json.Unmarshal([]byte(`{"status":"`+string(status)+`"}`), &post))
+
+			assert.Equal(t, status, post.Status)
+			assert.True(t, post.Status.IsKnown())
+		}
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		var post gowprest.Post
+		err := json.Unmarshal([]byte(`{
+			"id": 7,
+			"status": "in-review",
+			"format": "recipe",
+			"comment_status": "moderated"
+		}`), &post)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, post.ID)
+		assert.Equal(t, gowprest.PostStatus("in-review"), post.Status)
+		assert.False(t, post.Status.IsKnown())
+		assert.False(t, post.Format.IsKnown())
+		assert.False(t, post.CommentStatus.IsKnown())
+	})
+
+	t.Run("trashed post", func(t *testing.T) {
+		client := gowprest.NewClient(blogUrl).
+			WithBasicAuth(
+				os.Getenv("BLOG_USERNAME"),
+				os.Getenv("BLOG_APP_PASSWORD"),
+			)
+		defer client.Close()
+
+		post, err := client.Posts().Create(gowprest.PostData{Title: "Trashed"}).Do()
+		require.NoError(t, err)
+
+		trashed, err := client.Posts().Delete(post.ID).Do()
+		require.NoError(t, err)
+		assert.Equal(t, gowprest.StatusTrash, trashed.Status)
+		assert.Equal(t, post.ID, trashed.ID)
+
+		_, err = client.Posts().Delete(post.ID).Force().Do()
+		require.NoError(t, err)
+	})
+}
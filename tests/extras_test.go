@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type seoFields struct {
+	YoastHeadJSON struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"yoast_head_json"`
+	ACF map[string]any `json:"acf"`
+}
+
+func TestExtras(t *testing.T) {
+	server := wptest.NewServer()
+	defer server.Close()
+
+	seo := func(id int) any {
+		return map[string]any{"title": "SEO title", "description": "Described"}
+	}
+	server.RegisterField("post", "yoast_head_json", seo)
+	server.RegisterField("post", "acf", func(id int) any {
+		return map[string]any{"subtitle": "Sub", "rating": 4}
+	})
+	server.RegisterField("page", "acf", func(id int) any {
+		return map[string]any{"hero": "banner.jpg"}
+	})
+	server.RegisterField("comment", "karma", func(id int) any { return 7 })
+	server.RegisterField("category", "color", func(id int) any { return "#ff0000" })
+	server.RegisterField("taxonomy", "menu_icon", func(id int) any { return "dashicons-category" })
+	server.RegisterField("type", "translatable", func(id int) any { return true })
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	post, err := client.Posts().Create(gowprest.PostData{
+		Title:  "With plugins",
+		Status: gowprest.StatusPublished,
+	}).Do()
+	require.NoError(t, err)
+
+	t.Run("post", func(t *testing.T) {
+		retrieved, err := client.Posts().Retrieve(post.ID).Do()
+		require.NoError(t, err)
+
+		assert.Contains(t, retrieved.Extras, "yoast_head_json")
+		assert.Contains(t, retrieved.Extras, "acf")
+		assert.NotContains(t, retrieved.Extras, "title", "declared fields are not extras")
+
+		fields, err := gowprest.DecodeExtras[seoFields](retrieved.Extras)
+		require.NoError(t, err)
+		assert.Equal(t, "SEO title", fields.YoastHeadJSON.Title)
+		assert.Equal(t, "Sub", fields.ACF["subtitle"])
+
+		var rating int
+		found, err := retrieved.Extras.Decode("acf", &struct {
+			Rating *int `json:"rating"`
+		}{&rating})
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 4, rating)
+
+		found, err = retrieved.Extras.Decode("missing", &rating)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("created post", func(t *testing.T) {
+		assert.Contains(t, post.Extras, "acf")
+	})
+
+	t.Run("list", func(t *testing.T) {
+		posts, err := client.Posts().List().Do()
+		require.NoError(t, err)
+		require.NotEmpty(t, posts)
+		for _, p := range posts {
+			assert.Contains(t, p.Extras, "yoast_head_json")
+		}
+	})
+
+	t.Run("page", func(t *testing.T) {
+		pages, err := client.Pages().List().Do()
+		require.NoError(t, err)
+		require.NotEmpty(t, pages)
+
+		var acf map[string]string
+		found, err := pages[0].Extras.Decode("acf", &acf)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "banner.jpg", acf["hero"])
+	})
+
+	t.Run("comment", func(t *testing.T) {
+		comments, err := client.Comments().List().Do()
+		require.NoError(t, err)
+		require.NotEmpty(t, comments)
+
+		var karma int
+		_, err = comments[0].Extras.Decode("karma", &karma)
+		require.NoError(t, err)
+		assert.Equal(t, 7, karma)
+	})
+
+	t.Run("category", func(t *testing.T) {
+		categories, err := client.Categories().List().Do()
+		require.NoError(t, err)
+		require.NotEmpty(t, categories)
+		assert.JSONEq(t, `"#ff0000"`, string(categories[0].Extras["color"]))
+	})
+
+	t.Run("taxonomies, types and the index", func(t *testing.T) {
+		taxonomies, err := client.Taxonomies().List().Do()
+		require.NoError(t, err)
+		require.Contains(t, taxonomies, "category")
+		assert.JSONEq(t, `"dashicons-category"`, string(taxonomies["category"].Extras["menu_icon"]))
+
+		postType, err := client.Types().Retrieve("post").Do()
+		require.NoError(t, err)
+		assert.JSONEq(t, `true`, string(postType.Extras["translatable"]))
+
+		info, err := client.Discover()
+		require.NoError(t, err)
+		assert.Contains(t, info.Extras, "routes")
+	})
+}
+
+type subtitledPost struct {
+	gowprest.Post
+	Subtitle string `json:"subtitle"`
+}
+
+func TestExtrasEmbedded(t *testing.T) {
+	server := wptest.NewServer()
+	defer server.Close()
+	server.RegisterField("post", "subtitle", func(id int) any { return "Declared" })
+	server.RegisterField("post", "views", func(id int) any { return 12 })
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	posts, err := gowprest.NewResource[subtitledPost, gowprest.PostData](client, "posts").List().Do()
+	require.NoError(t, err)
+	require.NotEmpty(t, posts)
+
+	assert.Equal(t, "Declared", posts[0].Subtitle)
+	assert.NotContains(t, posts[0].Extras, "subtitle", "fields of the embedding struct are not extras")
+	assert.Contains(t, posts[0].Extras, "views")
+}
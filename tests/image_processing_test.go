@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// phonePhoto encodes a JPEG stored sideways, red on the left and blue on
+// the right, with an EXIF block asking viewers to turn it clockwise and
+// pointing at GPS data.
+func phonePhoto(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, image.Rect(0, 0, width/2, height), image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(width/2, 0, width, height), image.NewUniform(color.RGBA{B: 255, A: 255}), image.Point{}, draw.Src)
+
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}))
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	// Orientation: SHORT 6, rotate 90° clockwise
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 6)
+	// GPSInfo: LONG offset of the GPS IFD
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x8825)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 4)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 38)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	data := encoded.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func TestImageProcessing(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	// 1. A sideways phone photo is turned upright, scaled and stripped
+	photo := phonePhoto(t, 1200, 800)
+	var sent int64
+	media, err := client.Media().
+		Upload(bytes.NewReader(photo), "phone.jpg", "image/jpeg").
+		Process(gowprest.ImageProcessing{MaxDimension: 400, Quality: 70}).
+		Progress(func(s, _ int64) { sent = s }).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(media.ID).Force().Do()
+
+	assert.Equal(t, 267, media.MediaDetails.Width)
+	assert.Equal(t, 400, media.MediaDetails.Height)
+	assert.Less(t, sent, int64(len(photo)))
+
+	resp, err := http.Get(media.SourceURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	uploaded, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(uploaded), "Exif")
+
+	img, err := jpeg.Decode(bytes.NewReader(uploaded))
+	require.NoError(t, err)
+	r, _, b, _ := img.At(133, 20).RGBA()
+	assert.Greater(t, r, b, "the left of the stored image is on top")
+	r, _, b, _ = img.At(133, 380).RGBA()
+	assert.Greater(t, b, r)
+
+	// 2. PNG images keep their format and are scaled
+	wide, err := client.Media().
+		Upload(bytes.NewReader(pngImage(t, 1000, 500)), "wide.png", "image/png").
+		Process(gowprest.ImageProcessing{MaxDimension: 500}).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(wide.ID).Force().Do()
+	assert.Equal(t, "image/png", wide.MimeType)
+	assert.Equal(t, 500, wide.MediaDetails.Width)
+	assert.Equal(t, 250, wide.MediaDetails.Height)
+
+	// 3. Small images are not enlarged
+	small, err := client.Media().
+		Upload(bytes.NewReader(pngImage(t, 120, 80)), "small.png", "").
+		Process(gowprest.ImageProcessing{MaxDimension: 400}).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(small.ID).Force().Do()
+	assert.Equal(t, 120, small.MediaDetails.Width)
+
+	// 4. Other files pass through
+	text, err := client.Media().
+		Upload(bytes.NewReader([]byte("unchanged")), "notes.txt", "text/plain").
+		Process(gowprest.ImageProcessing{MaxDimension: 400}).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(text.ID).Force().Do()
+	assert.Equal(t, int64(9), text.MediaDetails.Filesize)
+}
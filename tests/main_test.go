@@ -0,0 +1,28 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest/wptest"
+)
+
+// TestMain runs the suite against a wptest fake when no live site is
+// configured through BLOG_URL.
+func TestMain(m *testing.M) {
+	if os.Getenv("BLOG_URL") != "" {
+		os.Exit(m.Run())
+	}
+
+	server := wptest.NewServer()
+
+	os.Setenv("BLOG_URL", server.URL)
+	os.Setenv("BLOG_USERNAME", wptest.Username)
+	os.Setenv("BLOG_APP_PASSWORD", wptest.Password)
+	blogUrl = server.URL
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
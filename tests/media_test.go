@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngImage encodes a solid image of the given size.
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestMediaAPI(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	post, err := client.Posts().Create(gowprest.PostData{
+		Title:  "Illustrated",
+		Status: gowprest.StatusPublished,
+	}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(post.ID).Force().Do()
+
+	// 1. Upload with progress
+	data := pngImage(t, 1200, 800)
+	var sent, total int64
+	media, err := client.Media().
+		Upload(bytes.NewReader(data), "sunset photo.png", "image/png").
+		Title("Sunset").
+		AltText("A sunset over the sea").
+		Caption("Taken at dusk").
+		Post(post.ID).
+		Progress(func(s, t int64) { sent, total = s, t }).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(media.ID).Force().Do()
+
+	assert.Equal(t, int64(len(data)), sent)
+	assert.Equal(t, int64(len(data)), total)
+
+	assert.Equal(t, gowprest.MediaTypeImage, media.MediaType)
+	assert.Equal(t, "image/png", media.MimeType)
+	assert.Equal(t, "Sunset", media.Title.Raw)
+	assert.Equal(t, "A sunset over the sea", media.AltText)
+	assert.Equal(t, "Taken at dusk", media.Caption.Raw)
+	assert.Equal(t, post.ID, media.Post)
+	assert.NotEmpty(t, media.SourceURL)
+
+	details := media.MediaDetails
+	assert.Equal(t, 1200, details.Width)
+	assert.Equal(t, 800, details.Height)
+	assert.Equal(t, int64(len(data)), details.Filesize)
+	require.Contains(t, details.Sizes, "thumbnail")
+	assert.Equal(t, 150, details.Sizes["thumbnail"].Width)
+	assert.Equal(t, 150, details.Sizes["thumbnail"].Height)
+	require.Contains(t, details.Sizes, "medium")
+	assert.Equal(t, 300, details.Sizes["medium"].Width)
+	assert.Equal(t, 200, details.Sizes["medium"].Height)
+	require.Contains(t, details.Sizes, "large")
+	assert.Equal(t, 1024, details.Sizes["large"].Width)
+	assert.Equal(t, media.SourceURL, details.Sizes["full"].SourceURL)
+	assert.NotContains(t, details.Sizes, "1536x1536")
+
+	// 2. Stream a reader of unknown length
+	text, err := client.Media().
+		Upload(io.MultiReader(strings.NewReader("hello "), strings.NewReader("world")), "notes.txt", "").
+		Progress(func(s, t int64) { sent, total = s, t }).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(text.ID).Force().Do()
+	assert.Equal(t, int64(11), sent)
+	assert.Equal(t, int64(-1), total)
+	assert.Equal(t, gowprest.MediaTypeFile, text.MediaType)
+	assert.Equal(t, "text/plain", text.MimeType)
+
+	// 3. List with filters
+	images, err := client.Media().List().MediaType(gowprest.MediaTypeImage).Parent(post.ID).Do()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Equal(t, media.ID, images[0].ID)
+
+	texts, err := client.Media().List().MimeType("text/plain").Include(media.ID, text.ID).Do()
+	require.NoError(t, err)
+	require.Len(t, texts, 1)
+	assert.Equal(t, text.ID, texts[0].ID)
+
+	// 4. Update and retrieve
+	_, err = client.Media().Update(gowprest.MediaData{
+		ID:          media.ID,
+		AltText:     "The sea at sunset",
+		Description: "Shot from the pier",
+	}).Do()
+	require.NoError(t, err)
+
+	retrieved, err := client.Media().Retrieve(media.ID).ContextEdit().Do()
+	require.NoError(t, err)
+	assert.Equal(t, "The sea at sunset", retrieved.AltText)
+	assert.Equal(t, "Shot from the pier", retrieved.Description.Raw)
+	assert.Equal(t, "Taken at dusk", retrieved.Caption.Raw)
+
+	// 5. Use it as the featured image
+	featured, err := client.Posts().Patch(post.ID).FeaturedMedia(media.ID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, media.ID, featured.FeaturedMedia)
+
+	// 6. Errors
+	_, err = client.Media().Upload(strings.NewReader("MZ"), "setup.exe", "application/octet-stream").Do()
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_upload_sideload_error", wpError.Code)
+
+	_, err = client.Media().Delete(text.ID).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_trash_not_supported", wpError.Code)
+
+	deleted, err := client.Media().Delete(text.ID).Force().Do()
+	require.NoError(t, err)
+	assert.Equal(t, text.ID, deleted.ID)
+}
+
+func TestMediaEdit(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	original, err := client.Media().
+		Upload(bytes.NewReader(pngImage(t, 400, 200)), "banner.png", "image/png").
+		Title("Banner").
+		AltText("Gradient banner").
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(original.ID).Force().Do()
+
+	// 1. Crop the top right quarter, turn it upright and mirror it
+	edited, err := client.Media().Edit(original.ID).
+		Crop(50, 0, 50, 50).
+		Rotate(90).
+		Flip(true, false).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(edited.ID).Force().Do()
+
+	assert.NotEqual(t, original.ID, edited.ID)
+	assert.Equal(t, "Banner", edited.Title.Raw)
+	assert.Equal(t, "Gradient banner", edited.AltText)
+	assert.Equal(t, 100, edited.MediaDetails.Width)
+	assert.Equal(t, 200, edited.MediaDetails.Height)
+
+	resp, err := http.Get(edited.SourceURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	img, err := png.Decode(resp.Body)
+	require.NoError(t, err)
+
+	// Rotating and mirroring transposes the crop, whose origin was (200, 0).
+	r, g, _, _ := img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(200), r>>8)
+	assert.Equal(t, uint32(0), g>>8)
+	_, g, _, _ = img.At(10, 0).RGBA()
+	assert.Equal(t, uint32(10), g>>8)
+
+	unchanged, err := client.Media().Retrieve(original.ID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, 400, unchanged.MediaDetails.Width)
+
+	// 2. Errors
+	_, err = client.Media().Edit(original.ID).Do()
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_image_not_edited", wpError.Code)
+
+	text, err := client.Media().Upload(strings.NewReader("plain"), "plain.txt", "text/plain").Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(text.ID).Force().Do()
+
+	_, err = client.Media().Edit(text.ID).Rotate(90).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_cannot_edit_file_type", wpError.Code)
+}
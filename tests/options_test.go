@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestOptionsAuth(t *testing.T) {
+	username := os.Getenv("BLOG_USERNAME")
+	password := os.Getenv("BLOG_APP_PASSWORD")
+
+	client := gowprest.NewClient(blogUrl)
+	defer client.Close()
+
+	admin := client.WithBasicAuth(username, password)
+	draft, err := admin.Posts().Create(gowprest.PostData{Title: "Options", Status: gowprest.StatusDraft}).Do()
+	require.NoError(t, err)
+	defer admin.Posts().Delete(draft.ID).Force().Do()
+
+	t.Run("credentials for one call", func(t *testing.T) {
+		retrieved, err := client.Posts().Retrieve(draft.ID).
+			With(gowprest.BasicAuth(username, password)).
+			Do()
+		require.NoError(t, err)
+		assert.Equal(t, draft.ID, retrieved.ID)
+
+		_, err = client.Posts().Retrieve(draft.ID).Do()
+		assert.Error(t, err, "the client itself stays anonymous")
+	})
+
+	t.Run("other credentials than the client's", func(t *testing.T) {
+		_, err := admin.Posts().List().StatusDraft().
+			With(gowprest.BasicAuth(username, "wrong password")).
+			Do()
+		assert.Error(t, err)
+
+		_, err = admin.Posts().List().StatusDraft().Do()
+		assert.NoError(t, err)
+	})
+}
+
+func TestRequestOptions(t *testing.T) {
+	var mu sync.Mutex
+	var received []*http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r)
+		mu.Unlock()
+
+		if r.URL.Query().Get("search") == "slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode([]any{}))
+	}))
+	defer server.Close()
+
+	last := func() *http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return received[len(received)-1]
+	}
+
+	client := gowprest.NewClient(server.URL)
+	defer client.Close()
+
+	t.Run("header", func(t *testing.T) {
+		_, err := client.Categories().List().
+			With(gowprest.Header("X-Request-ID", "abc"), gowprest.Header("X-Request-ID", "def")).
+			Do()
+		require.NoError(t, err)
+		assert.Equal(t, "def", last().Header.Get("X-Request-ID"))
+
+		_, err = client.Categories().List().Do()
+		require.NoError(t, err)
+		assert.Empty(t, last().Header.Get("X-Request-ID"))
+	})
+
+	t.Run("locale", func(t *testing.T) {
+		_, err := client.Pages().List().With(gowprest.Locale("user")).Do()
+		require.NoError(t, err)
+		assert.Equal(t, "user", last().URL.Query().Get("_locale"))
+
+		_, err = client.Posts().List().Search("x").With(gowprest.Locale("de_DE")).Do()
+		require.NoError(t, err)
+		assert.Equal(t, "de_DE", last().URL.Query().Get("_locale"))
+		assert.Equal(t, "x", last().URL.Query().Get("search"))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, err := client.Posts().List().Search("slow").With(gowprest.Timeout(50 * time.Millisecond)).Do()
+		assert.Error(t, err)
+
+		_, err = client.Posts().List().Search("slow").Do()
+		assert.NoError(t, err)
+	})
+
+	t.Run("cookie credentials sent once", func(t *testing.T) {
+		cookies := gowprest.CookieAuthentication{
+			Cookies: []*http.Cookie{{Name: "wordpress_logged_in", Value: "admin|token"}},
+			Nonce:   "abc123",
+		}
+
+		// Drafts are always requested with credentials, which the option
+		// has already applied.
+		_, err := client.Posts().List().StatusDraft().With(gowprest.Auth(cookies)).Do()
+		require.NoError(t, err)
+		assert.Len(t, last().Cookies(), 1)
+		assert.Equal(t, []string{"abc123"}, last().Header.Values("X-WP-Nonce"))
+
+		_, err = client.Categories().List().With(gowprest.Auth(cookies)).Do()
+		require.NoError(t, err)
+		assert.Len(t, last().Cookies(), 1)
+	})
+
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.Posts().List().Search("slow").With(gowprest.Context(ctx)).Do()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = client.With(gowprest.Context(cancelled)).Media().
+			Upload(strings.NewReader("hello"), "hello.txt", "text/plain").
+			Do()
+		assert.ErrorIs(t, err, context.Canceled)
+
+		_, err = client.Posts().List().Search("slow").Do()
+		assert.NoError(t, err)
+	})
+
+	t.Run("derived client", func(t *testing.T) {
+		german := client.With(gowprest.Locale("de_DE"), gowprest.Header("X-Tenant", "berlin"))
+
+		_, err := german.Comments().List().Do()
+		require.NoError(t, err)
+		assert.Equal(t, "de_DE", last().URL.Query().Get("_locale"))
+		assert.Equal(t, "berlin", last().Header.Get("X-Tenant"))
+
+		_, err = german.Comments().List().With(gowprest.Header("X-Tenant", "munich")).Do()
+		require.NoError(t, err)
+		assert.Equal(t, "munich", last().Header.Get("X-Tenant"))
+
+		_, err = client.Comments().List().Do()
+		require.NoError(t, err)
+		assert.False(t, last().URL.Query().Has("_locale"))
+	})
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchUpdates(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	t.Run("post fields can be cleared", func(t *testing.T) {
+		category, err := client.Categories().Create(gowprest.CategoryData{Name: faker.Word() + "-patch"}).Do()
+		require.NoError(t, err)
+		defer client.Categories().Delete(category.ID).Force().Do()
+
+		post, err := client.Posts().Create(gowprest.PostData{
+			Title:      faker.Sentence(),
+			Excerpt:    faker.Sentence(),
+			Status:     gowprest.StatusPublished,
+			Sticky:     true,
+			Categories: []int{category.ID},
+			Meta:       map[string]any{"footnotes": "[]"},
+		}).Do()
+		require.NoError(t, err)
+		defer client.Posts().Delete(post.ID).Force().Do()
+
+		// Update cannot express any of this: every field below is a zero value.
+		patched, err := client.Posts().Patch(post.ID).
+			Sticky(false).
+			Excerpt("").
+			FeaturedMedia(0).
+			Tags().
+			Do()
+		require.NoError(t, err)
+
+		assert.False(t, patched.Sticky)
+		assert.Empty(t, patched.Tags)
+		assert.Equal(t, 0, patched.FeaturedMedia)
+		assert.Equal(t, post.Title.Rendered, patched.Title.Rendered, "untouched fields are kept")
+		assert.Equal(t, []int{category.ID}, patched.Categories, "untouched fields are kept")
+
+		retrieved, err := client.Posts().Retrieve(post.ID).ContextEdit().Do()
+		require.NoError(t, err)
+		assert.Empty(t, retrieved.Excerpt.Rendered)
+	})
+
+	t.Run("page parent can be reset", func(t *testing.T) {
+		parent, err := client.Pages().Create(gowprest.PageData{Title: faker.Sentence()}).Do()
+		require.NoError(t, err)
+		defer client.Pages().Delete(parent.ID).Force().Do()
+
+		child, err := client.Pages().Create(gowprest.PageData{Title: faker.Sentence(), Parent: parent.ID}).Do()
+		require.NoError(t, err)
+		defer client.Pages().Delete(child.ID).Force().Do()
+		require.Equal(t, parent.ID, child.Parent)
+
+		patched, err := client.Pages().Patch(child.ID).Parent(0).MenuOrder(0).Do()
+		require.NoError(t, err)
+		assert.Equal(t, 0, patched.Parent)
+	})
+
+	t.Run("category description can be emptied", func(t *testing.T) {
+		category, err := client.Categories().Create(gowprest.CategoryData{
+			Name:        faker.Word() + "-described",
+			Description: faker.Sentence(),
+		}).Do()
+		require.NoError(t, err)
+		defer client.Categories().Delete(category.ID).Force().Do()
+
+		patched, err := client.Categories().Patch(category.ID).Description("").Do()
+		require.NoError(t, err)
+		assert.Empty(t, patched.Description)
+		assert.Equal(t, category.Name, patched.Name)
+	})
+
+	t.Run("comment author url can be emptied", func(t *testing.T) {
+		posts, err := client.Posts().List().Do()
+		require.NoError(t, err)
+		require.NotEmpty(t, posts)
+
+		comment, err := client.Comments().Create(gowprest.CommentData{
+			Post:      posts[0].ID,
+			Content:   faker.Paragraph(),
+			AuthorURL: "https://example.org",
+		}).Do()
+		require.NoError(t, err)
+		defer client.Comments().Delete(comment.ID).Force().Do()
+
+		patched, err := client.Comments().Patch(comment.ID).AuthorURL("").Do()
+		require.NoError(t, err)
+		assert.Empty(t, patched.AuthorURL)
+	})
+}
+
+func TestPatchUnencodable(t *testing.T) {
+	client := gowprest.NewClient(blogUrl)
+	defer client.Close()
+
+	_, err := client.Posts().Patch(1).Set("rating", math.NaN()).Do()
+	assert.Error(t, err)
+	_, err = client.Pages().Patch(1).Set("rating", math.NaN()).Do()
+	assert.Error(t, err)
+	_, err = client.Comments().Patch(1).Set("rating", math.NaN()).Do()
+	assert.Error(t, err)
+	_, err = client.Categories().Patch(1).Set("rating", math.NaN()).Do()
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Plugins are site-wide, so these tests change a server of their own.
+func TestPluginsAPI(t *testing.T) {
+	server := wptest.NewServer()
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	// 1. List and retrieve the bundled plugins
+	plugins, err := client.Plugins().List().Do()
+	require.NoError(t, err)
+	require.Len(t, plugins, 2)
+	assert.Equal(t, "akismet/akismet", plugins[0].Plugin)
+	assert.Equal(t, "hello", plugins[1].Plugin)
+
+	hello, err := client.Plugins().Retrieve("hello.php").Do()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello Dolly", hello.Name)
+	assert.Equal(t, gowprest.PluginInactive, hello.Status)
+	assert.False(t, hello.IsActive())
+
+	found, err := client.Plugins().List().Search("spam").Do()
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "akismet/akismet", found[0].Plugin)
+
+	// 2. Install, activate and filter by status
+	installed, err := client.Plugins().Install("classic-editor").Status(gowprest.PluginActive).Do()
+	require.NoError(t, err)
+	assert.Equal(t, "classic-editor/classic-editor", installed.Plugin)
+	assert.True(t, installed.IsActive())
+	assert.NotEmpty(t, installed.Version)
+
+	activated, err := client.Plugins().Activate("akismet/akismet").Do()
+	require.NoError(t, err)
+	assert.Equal(t, gowprest.PluginActive, activated.Status)
+
+	active, err := client.Plugins().List().StatusIn(gowprest.PluginActive).Do()
+	require.NoError(t, err)
+	assert.Len(t, active, 2)
+
+	// 3. Deactivate and delete
+	_, err = client.Plugins().Delete(installed.Plugin).Do()
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_cannot_delete_active_plugin", wpError.Code)
+
+	deactivated, err := client.Plugins().Deactivate(installed.Plugin).Do()
+	require.NoError(t, err)
+	assert.Equal(t, gowprest.PluginInactive, deactivated.Status)
+
+	deleted, err := client.Plugins().Delete(installed.Plugin).Do()
+	require.NoError(t, err)
+	assert.Equal(t, "Classic Editor", deleted.Name)
+
+	_, err = client.Plugins().Retrieve(installed.Plugin).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_plugin_not_found", wpError.Code)
+
+	// 4. Network activation needs a multisite network
+	_, err = client.Plugins().Activate("hello").NetworkWide().Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_invalid_param", wpError.Code)
+
+	server.Multisite = true
+	network, err := client.Plugins().Activate("hello").NetworkWide().Do()
+	require.NoError(t, err)
+	assert.Equal(t, gowprest.PluginNetworkActive, network.Status)
+	assert.True(t, network.IsActive())
+
+	// 5. Sites that need filesystem credentials
+	server.FilesystemCredentialsRequired = true
+	_, err = client.Plugins().Install("query-monitor").Do()
+	require.ErrorIs(t, err, gowprest.ErrFilesystemCredentialsRequired)
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "unable_to_connect_to_filesystem", wpError.Code)
+
+	_, err = client.Plugins().Deactivate("akismet/akismet").Do()
+	require.NoError(t, err)
+
+	_, err = client.Plugins().Delete("akismet/akismet").Do()
+	require.ErrorIs(t, err, gowprest.ErrFilesystemCredentialsRequired)
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "fs_unavailable", wpError.Code)
+
+	// 6. Other errors
+	server.FilesystemCredentialsRequired = false
+	_, err = client.Plugins().Install("no-such-plugin").Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "plugins_api_failed", wpError.Code)
+
+	_, err = client.Plugins().Install("akismet").Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "folder_exists", wpError.Code)
+
+	anonymous := gowprest.NewClient(server.URL)
+	defer anonymous.Close()
+	_, err = anonymous.Plugins().List().Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_cannot_view_plugins", wpError.Code)
+}
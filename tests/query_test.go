@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postIDs(posts []gowprest.Post) []int {
+	ids := []int{}
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	return ids
+}
+
+func TestListQuery(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	parent, err := client.Categories().Create(gowprest.CategoryData{Name: faker.Word() + "-parent"}).Do()
+	require.NoError(t, err)
+	defer client.Categories().Delete(parent.ID).Force().Do()
+
+	child, err := client.Categories().Create(gowprest.CategoryData{Name: faker.Word() + "-child", Parent: parent.ID}).Do()
+	require.NoError(t, err)
+	defer client.Categories().Delete(child.ID).Force().Do()
+
+	inParent, err := client.Posts().Create(gowprest.PostData{
+		Title:      "In parent",
+		Slug:       "in-parent-" + faker.Word(),
+		Status:     gowprest.StatusPublished,
+		Categories: []int{parent.ID},
+	}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(inParent.ID).Force().Do()
+
+	inChild, err := client.Posts().Create(gowprest.PostData{
+		Title:      "In child",
+		Slug:       "in-child-" + faker.Word(),
+		Status:     gowprest.StatusPublished,
+		Categories: []int{child.ID},
+	}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(inChild.ID).Force().Do()
+
+	inBoth, err := client.Posts().Create(gowprest.PostData{
+		Title:      "In both",
+		Status:     gowprest.StatusDraft,
+		Categories: []int{parent.ID, child.ID},
+	}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(inBoth.ID).Force().Do()
+
+	created := []int{inParent.ID, inChild.ID, inBoth.ID}
+
+	t.Run("status in", func(t *testing.T) {
+		posts, err := client.Posts().List().
+			Include(created...).
+			StatusIn(gowprest.StatusPublished, gowprest.StatusDraft).
+			Do()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, created, postIDs(posts))
+
+		posts, err = client.Posts().List().Include(created...).StatusDraft().Do()
+		require.NoError(t, err)
+		assert.Equal(t, []int{inBoth.ID}, postIDs(posts))
+	})
+
+	t.Run("draft status requires authentication", func(t *testing.T) {
+		anonymous := gowprest.NewClient(blogUrl)
+		defer anonymous.Close()
+
+		_, err := anonymous.Posts().List().StatusDraft().Do()
+		require.Error(t, err)
+	})
+
+	t.Run("authors", func(t *testing.T) {
+		posts, err := client.Posts().List().Include(created...).Author(inParent.Author, inParent.Author+1000).Do()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int{inParent.ID, inChild.ID}, postIDs(posts))
+	})
+
+	t.Run("slugs", func(t *testing.T) {
+		posts, err := client.Posts().List().Slugs(inParent.Slug, inChild.Slug).Do()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int{inParent.ID, inChild.ID}, postIDs(posts))
+	})
+
+	t.Run("categories", func(t *testing.T) {
+		posts, err := client.Posts().List().Include(created...).StatusAny().Categories(parent.ID).Do()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int{inParent.ID, inBoth.ID}, postIDs(posts))
+
+		posts, err = client.Posts().List().Include(created...).StatusAny().CategoriesExclude(child.ID).Do()
+		require.NoError(t, err)
+		assert.Equal(t, []int{inParent.ID}, postIDs(posts))
+	})
+
+	t.Run("tax query", func(t *testing.T) {
+		posts, err := client.Posts().List().Include(created...).StatusAny().
+			CategoriesQuery(gowprest.TaxQuery{Terms: []int{parent.ID}, IncludeChildren: true}).
+			Do()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, created, postIDs(posts))
+
+		posts, err = client.Posts().List().Include(created...).StatusAny().
+			CategoriesQuery(gowprest.TaxQuery{Terms: []int{parent.ID, child.ID}, Operator: "AND"}).
+			Do()
+		require.NoError(t, err)
+		assert.Equal(t, []int{inBoth.ID}, postIDs(posts))
+
+		posts, err = client.Posts().List().Include(created...).StatusAny().
+			CategoriesExcludeQuery(gowprest.TaxQuery{Terms: []int{parent.ID}, IncludeChildren: true}).
+			Do()
+		require.NoError(t, err)
+		assert.Empty(t, posts)
+	})
+
+	t.Run("comments on several posts", func(t *testing.T) {
+		for _, postID := range []int{inParent.ID, inChild.ID} {
+			_, err := client.Comments().Create(gowprest.CommentData{Post: postID, Content: faker.Sentence()}).Do()
+			require.NoError(t, err)
+		}
+
+		comments, err := client.Comments().List().Post(inParent.ID, inChild.ID).Do()
+		require.NoError(t, err)
+		assert.Len(t, comments, 2)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		draft, err := client.Pages().Create(gowprest.PageData{Title: "Draft page", Status: gowprest.StatusDraft}).Do()
+		require.NoError(t, err)
+		defer client.Pages().Delete(draft.ID).Force().Do()
+
+		pages, err := client.Pages().List().Include(draft.ID).StatusIn(gowprest.StatusDraft, gowprest.StatusPending).Do()
+		require.NoError(t, err)
+		require.Len(t, pages, 1)
+		assert.Equal(t, draft.ID, pages[0].ID)
+	})
+}
+
+// The fake server prefers the list form when both are sent, so the
+// arguments are checked on the wire.
+func TestTaxQueryReplacesList(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL)
+	defer client.Close()
+
+	_, err := client.Posts().List().
+		CategoriesQuery(gowprest.TaxQuery{Terms: []int{1}, IncludeChildren: true, Operator: "AND"}).
+		Categories(2).
+		Do()
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"categories[]": {"2"}}, query)
+
+	_, err = client.Posts().List().
+		Tags(3).
+		TagsQuery(gowprest.TaxQuery{Terms: []int{4}, Operator: "AND"}).
+		Do()
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"tags[terms][]": {"4"}, "tags[operator]": {"AND"}}, query)
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customPost struct {
+	gowprest.Post
+}
+
+type customPostData struct {
+	gowprest.PostData
+}
+
+func TestGenericResource(t *testing.T) {
+	blogUrl := os.Getenv("BLOG_URL")
+	username := os.Getenv("BLOG_USERNAME")
+	password := os.Getenv("BLOG_APP_PASSWORD")
+
+	if blogUrl == "" || username == "" || password == "" {
+		t.Skip("Skipping test; BLOG_URL, BLOG_USERNAME, or BLOG_APP_PASSWORD not set")
+	}
+
+	client := gowprest.NewClient(blogUrl).WithBasicAuth(username, password)
+	defer client.Close()
+
+	// 1. Discover the resource through the types endpoint
+	postType, err := client.Types().Retrieve("post").Do()
+	require.NoError(t, err)
+	assert.Equal(t, "posts", postType.RestBase)
+
+	resource, err := gowprest.DiscoverResource[customPost, customPostData](client, "post")
+	require.NoError(t, err)
+
+	// 2. Create
+	title := faker.Sentence()
+	created, err := resource.Create(customPostData{gowprest.PostData{
+		Title:   title,
+		Content: faker.Paragraph(),
+		Status:  gowprest.StatusPublished,
+	}}).Do()
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	// 3. Retrieve
+	retrieved, err := resource.Retrieve(created.ID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, retrieved.ID)
+
+	// 4. List
+	items, err := resource.List().Include(created.ID).Do()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, created.ID, items[0].ID)
+
+	// 5. Update
+	updated, err := resource.Update(created.ID, customPostData{gowprest.PostData{
+		Title: title + " (Updated)",
+	}}).Do()
+	require.NoError(t, err)
+	assert.Contains(t, updated.Title.Rendered, "(Updated)")
+
+	// 6. Delete
+	deleted, err := resource.Delete(created.ID).Force().Do()
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, deleted.ID)
+}
+
+func TestDiscoverResourceNull(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("null"))
+	}))
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL)
+	defer client.Close()
+
+	_, err := gowprest.DiscoverResource[customPost, customPostData](client, "product")
+	assert.ErrorIs(t, err, gowprest.ErrPostTypeUndescribed)
+}
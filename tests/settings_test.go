@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Settings are site-wide, so these tests change a server of their own.
+func TestSettingsAPI(t *testing.T) {
+	server := wptest.NewServer()
+	defer server.Close()
+	server.RegisterSetting("newsletter_sender", "news@example.org")
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	// 1. Read the typed core settings and a plugin setting
+	settings, err := client.Settings().Retrieve().Do()
+	require.NoError(t, err)
+	assert.Equal(t, server.Name, settings.Title)
+	assert.Equal(t, 10, settings.PostsPerPage)
+	assert.Equal(t, gowprest.ShowOnFrontPosts, settings.ShowOnFront)
+	assert.NotZero(t, settings.DefaultCategory)
+	assert.True(t, settings.UseSmilies)
+
+	var sender string
+	found, err := settings.Extras.Decode("newsletter_sender", &sender)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "news@example.org", sender)
+
+	// 2. Patch only what changes, zero values included
+	page, err := client.Pages().Create(gowprest.PageData{
+		Title:  "Welcome",
+		Status: gowprest.StatusPublished,
+	}).Do()
+	require.NoError(t, err)
+
+	updated, err := client.Settings().Patch().
+		Title("Renamed Blog").
+		Timezone("Europe/Paris").
+		PostsPerPage(5).
+		UseSmilies(false).
+		ShowOnFront(gowprest.ShowOnFrontPage).
+		PageOnFront(page.ID).
+		Set("newsletter_sender", "editors@example.org").
+		Do()
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed Blog", updated.Title)
+	assert.Equal(t, "Europe/Paris", updated.Timezone)
+	assert.Equal(t, 5, updated.PostsPerPage)
+	assert.False(t, updated.UseSmilies)
+	assert.Equal(t, settings.DateFormat, updated.DateFormat)
+	assert.Equal(t, settings.Description, updated.Description)
+
+	type newsletterSettings struct {
+		Sender string `json:"newsletter_sender"`
+	}
+	newsletter, err := gowprest.DecodeExtras[newsletterSettings](updated.Extras)
+	require.NoError(t, err)
+	assert.Equal(t, "editors@example.org", newsletter.Sender)
+
+	// The public index reads the same options.
+	info, err := client.Discover()
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed Blog", info.Name)
+	assert.Equal(t, "Europe/Paris", info.Location().String())
+	assert.Equal(t, page.ID, info.PageOnFront)
+
+	// 3. Reset a plugin setting to its default
+	reset, err := client.Settings().Patch().Set("newsletter_sender", nil).Do()
+	require.NoError(t, err)
+	newsletter, err = gowprest.DecodeExtras[newsletterSettings](reset.Extras)
+	require.NoError(t, err)
+	assert.Equal(t, "news@example.org", newsletter.Sender)
+
+	// 4. Errors
+	_, err = client.Settings().Patch().ShowOnFront("archive").Do()
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_invalid_param", wpError.Code)
+
+	anonymous := gowprest.NewClient(server.URL)
+	defer anonymous.Close()
+	_, err = anonymous.Settings().Retrieve().Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_forbidden", wpError.Code)
+}
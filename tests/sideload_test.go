@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSideloadMedia(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	photo := pngImage(t, 320, 240)
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"), "credentials must not reach the remote host")
+		switch r.URL.Path {
+		case "/images/harbour.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(photo)
+		case "/render":
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("Content-Disposition", `inline; filename="lighthouse"`)
+			w.Write(photo)
+		case "/article.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer remote.Close()
+
+	post, err := client.Posts().Create(gowprest.PostData{
+		Title:  "Imported",
+		Status: gowprest.StatusPublished,
+	}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(post.ID).Force().Do()
+
+	// 1. Sideload as the featured image
+	media, created, err := client.Media().
+		Sideload(remote.URL + "/images/harbour.png").
+		AltText("Boats in the harbour").
+		Caption("The old harbour").
+		Deduplicate().
+		ForPost(post.ID).
+		Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(media.ID).Force().Do()
+
+	assert.True(t, created)
+	assert.Equal(t, "Boats in the harbour", media.AltText)
+	assert.Equal(t, "The old harbour", media.Caption.Raw)
+	assert.Equal(t, post.ID, media.Post)
+	assert.Equal(t, 320, media.MediaDetails.Width)
+	assert.Contains(t, media.SourceURL, "harbour")
+
+	featured, err := client.Posts().Retrieve(post.ID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, media.ID, featured.FeaturedMedia)
+
+	// 2. The same content is reused
+	page, err := client.Pages().Create(gowprest.PageData{
+		Title:  "Harbour",
+		Status: gowprest.StatusPublished,
+	}).Do()
+	require.NoError(t, err)
+	defer client.Pages().Delete(page.ID).Force().Do()
+
+	reused, created, err := client.Media().
+		Sideload(remote.URL + "/render").
+		Deduplicate().
+		ForPage(page.ID).
+		Do()
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, media.ID, reused.ID)
+
+	featuredPage, err := client.Pages().Retrieve(page.ID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, media.ID, featuredPage.FeaturedMedia)
+
+	// 3. Without deduplication a copy is named after Content-Disposition
+	copied, created, err := client.Media().Sideload(remote.URL + "/render").Do()
+	require.NoError(t, err)
+	defer client.Media().Delete(copied.ID).Force().Do()
+	assert.True(t, created)
+	assert.NotEqual(t, media.ID, copied.ID)
+	assert.Contains(t, copied.SourceURL, "lighthouse.png")
+
+	// 4. Limits
+	_, _, err = client.Media().Sideload(remote.URL + "/article.html").Do()
+	assert.ErrorIs(t, err, gowprest.ErrDownloadType)
+
+	_, _, err = client.Media().Sideload(remote.URL + "/images/harbour.png").MaxSize(100).Do()
+	assert.ErrorIs(t, err, gowprest.ErrDownloadTooLarge)
+
+	_, _, err = client.Media().Sideload(remote.URL + "/missing.png").Do()
+	assert.ErrorContains(t, err, "404")
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagsAPI(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	tagName := faker.Word() + "-tag"
+	tagDescription := faker.Sentence()
+
+	// 1. Create Tag
+	newTag, err := client.Tags().Create(gowprest.TagData{
+		Name:        tagName,
+		Description: tagDescription,
+	}).Do()
+
+	require.Nil(t, err)
+	assert.Equal(t, tagName, newTag.Name)
+	assert.Equal(t, tagDescription, newTag.Description)
+	assert.Equal(t, "post_tag", newTag.Taxonomy)
+	tagID := newTag.ID
+
+	// 2. List Tags
+	tags, err := client.Tags().List().Search(tagName).Do()
+	require.Nil(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, tagID, tags[0].ID)
+
+	tags, err = client.Tags().List().Slug(newTag.Slug).Do()
+	require.Nil(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, tagID, tags[0].ID)
+
+	// 3. Tags of a post
+	post, err := client.Posts().Create(gowprest.PostData{
+		Title:  faker.Sentence(),
+		Status: gowprest.StatusPublished,
+		Tags:   []int{tagID},
+	}).Do()
+	require.Nil(t, err)
+	defer client.Posts().Delete(post.ID).Force().Do()
+
+	tags, err = client.Tags().List().Post(post.ID).Do()
+	require.Nil(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, tagID, tags[0].ID)
+	assert.Equal(t, 1, tags[0].Count)
+
+	posts, err := client.Posts().List().Tags(tagID).Do()
+	require.Nil(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, post.ID, posts[0].ID)
+
+	// 4. Hide empty and order by count
+	empty, err := client.Tags().Create(gowprest.TagData{Name: faker.Word() + "-empty"}).Do()
+	require.Nil(t, err)
+	defer client.Tags().Delete(empty.ID).Force().Do()
+
+	tags, err = client.Tags().List().Include(tagID, empty.ID).HideEmpty(true).Do()
+	require.Nil(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, tagID, tags[0].ID)
+
+	tags, err = client.Tags().List().Include(tagID, empty.ID).OrderByCount().OrderDesc().Do()
+	require.Nil(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, tagID, tags[0].ID)
+
+	// 5. Retrieve Tag
+	retrievedTag, err := client.Tags().Retrieve(tagID).Do()
+	require.Nil(t, err)
+	assert.Equal(t, tagID, retrievedTag.ID)
+	assert.Equal(t, tagName, retrievedTag.Name)
+
+	// 6. Update Tag
+	updatedName := tagName + " (Updated)"
+	updatedTag, err := client.Tags().Update(gowprest.TagData{
+		ID:   tagID,
+		Name: updatedName,
+	}).Do()
+
+	require.Nil(t, err)
+	assert.Equal(t, tagID, updatedTag.ID)
+	assert.Equal(t, updatedName, updatedTag.Name)
+
+	patchedTag, err := client.Tags().Patch(tagID).Description("").Do()
+	require.Nil(t, err)
+	assert.Empty(t, patchedTag.Description)
+	assert.Equal(t, updatedName, patchedTag.Name)
+
+	// 7. Delete Tag
+	_, err = client.Tags().Delete(tagID).Do()
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_trash_not_supported", wpError.Code)
+
+	deletedTag, err := client.Tags().Delete(tagID).Force().Do()
+	require.Nil(t, err)
+	assert.Equal(t, tagID, deletedTag.ID)
+
+	// 8. Verify Deletion
+	_, err = client.Tags().Retrieve(tagID).Do()
+	assert.NotNil(t, err, "Tag should not be found after deletion")
+
+	retrievedPost, err := client.Posts().Retrieve(post.ID).Do()
+	require.Nil(t, err)
+	assert.Empty(t, retrievedPost.Tags)
+}
+
+func TestTagsTransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := gowprest.NewClient(server.URL)
+	defer client.Close()
+
+	_, err := client.Tags().Create(gowprest.TagData{Name: "Offline"}).Do()
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	_, err = client.Tags().Retrieve(1).Do()
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	_, err = client.Tags().Update(gowprest.TagData{ID: 1, Name: "Offline"}).Do()
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	_, err = client.Tags().Patch(1).Name("Offline").Do()
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	_, err = client.Tags().Delete(1).Force().Do()
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+}
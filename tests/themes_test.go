@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Activating a theme changes the whole site, so these tests use a server of
+// their own.
+func TestThemesAPI(t *testing.T) {
+	server := wptest.NewServer()
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	// 1. Report the active theme and what it supports
+	active, err := client.Themes().List().StatusIn(gowprest.ThemeActive).Do()
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	theme := active[0]
+	assert.Equal(t, "twentytwentyfive", theme.Stylesheet)
+	assert.Equal(t, gowprest.ThemeActive, theme.Status)
+	assert.True(t, theme.IsBlockTheme)
+	assert.False(t, theme.IsChild())
+	assert.NotEmpty(t, theme.Version)
+	assert.Equal(t, "Twenty Twenty-Five", theme.Name.Raw)
+	assert.True(t, theme.ThemeSupports.Has("block-templates"))
+	assert.False(t, theme.ThemeSupports.Has("title-tag"))
+	assert.False(t, theme.ThemeSupports.Has("custom-header"))
+
+	inactive, err := client.Themes().List().StatusIn(gowprest.ThemeInactive).Do()
+	require.NoError(t, err)
+	assert.Len(t, inactive, 2)
+
+	// 2. Retrieve a classic theme
+	classic, err := client.Themes().Retrieve("twentytwentyone").Do()
+	require.NoError(t, err)
+	assert.Equal(t, "twentytwentyone", classic.Template)
+	assert.False(t, classic.IsBlockTheme)
+	assert.Equal(t, gowprest.ThemeInactive, classic.Status)
+	assert.True(t, classic.ThemeSupports.Has("custom-logo"))
+	assert.Contains(t, classic.ThemeSupports["html5"], "navigation-widgets")
+
+	// 3. Core has no activation route
+	_, err = client.Themes().Activate("twentytwentyone").Do()
+	require.ErrorIs(t, err, gowprest.ErrThemeActivationUnsupported)
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_no_route", wpError.Code)
+
+	// 4. Activate where the host allows it
+	server.AllowThemeActivation = true
+	activated, err := client.Themes().Activate("twentytwentyone").Do()
+	require.NoError(t, err)
+	assert.Equal(t, gowprest.ThemeActive, activated.Status)
+
+	active, err = client.Themes().List().StatusIn(gowprest.ThemeActive).Do()
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "twentytwentyone", active[0].Stylesheet)
+
+	// 5. Errors
+	_, err = client.Themes().Retrieve("missing").Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_theme_not_found", wpError.Code)
+
+	anonymous := gowprest.NewClient(server.URL)
+	defer anonymous.Close()
+	_, err = anonymous.Themes().List().Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_cannot_view_themes", wpError.Code)
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsert(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	slug := func() string {
+		return strings.ToLower(faker.Word() + "-" + faker.Word() + "-upsert")
+	}
+
+	t.Run("post is created then updated", func(t *testing.T) {
+		data := gowprest.PostData{Slug: slug(), Title: "Imported"}
+
+		first, created, err := client.Posts().Upsert(data).Do()
+		require.NoError(t, err)
+		defer client.Posts().Delete(first.ID).Force().Do()
+		assert.True(t, created)
+
+		// The draft is only visible with status=any.
+		data.Title = "Imported again"
+		second, created, err := client.Posts().Upsert(data).Do()
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, first.ID, second.ID)
+		assert.Equal(t, "Imported again", second.Title.Rendered)
+	})
+
+	t.Run("trashed post", func(t *testing.T) {
+		data := gowprest.PostData{Slug: slug(), Title: "Trashed import", Status: gowprest.StatusPublished}
+
+		original, _, err := client.Posts().Upsert(data).Do()
+		require.NoError(t, err)
+		defer client.Posts().Delete(original.ID).Force().Do()
+
+		_, err = client.Posts().Delete(original.ID).Do()
+		require.NoError(t, err)
+
+		restored, created, err := client.Posts().Upsert(data).RestoreTrashed().Do()
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, original.ID, restored.ID)
+		assert.Equal(t, gowprest.StatusPublished, restored.Status)
+		assert.Equal(t, data.Slug, restored.Slug)
+
+		_, err = client.Posts().Delete(original.ID).Do()
+		require.NoError(t, err)
+
+		fresh, created, err := client.Posts().Upsert(data).Do()
+		require.NoError(t, err)
+		defer client.Posts().Delete(fresh.ID).Force().Do()
+		assert.True(t, created, "trashed posts are ignored by default")
+		assert.NotEqual(t, original.ID, fresh.ID)
+	})
+
+	t.Run("page under parent", func(t *testing.T) {
+		parent, err := client.Pages().Create(gowprest.PageData{Title: faker.Sentence()}).Do()
+		require.NoError(t, err)
+		defer client.Pages().Delete(parent.ID).Force().Do()
+
+		data := gowprest.PageData{Slug: slug(), Title: "Child", Parent: parent.ID}
+
+		child, created, err := client.Pages().Upsert(data).MatchParent().Do()
+		require.NoError(t, err)
+		defer client.Pages().Delete(child.ID).Force().Do()
+		assert.True(t, created)
+
+		again, created, err := client.Pages().Upsert(data).MatchParent().Do()
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, child.ID, again.ID)
+	})
+
+	t.Run("category", func(t *testing.T) {
+		data := gowprest.CategoryData{Slug: slug(), Name: faker.Word() + " upsert"}
+
+		category, created, err := client.Categories().Upsert(data).Do()
+		require.NoError(t, err)
+		defer client.Categories().Delete(category.ID).Force().Do()
+		assert.True(t, created)
+
+		data.Description = "Updated by import"
+		updated, created, err := client.Categories().Upsert(data).Do()
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, category.ID, updated.ID)
+		assert.Equal(t, "Updated by import", updated.Description)
+	})
+
+	t.Run("slug is required", func(t *testing.T) {
+		_, _, err := client.Posts().Upsert(gowprest.PostData{Title: "No slug"}).Do()
+		assert.Error(t, err)
+	})
+}
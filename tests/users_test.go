@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersAPI(t *testing.T) {
+	client := gowprest.NewClient(blogUrl).
+		WithBasicAuth(
+			os.Getenv("BLOG_USERNAME"),
+			os.Getenv("BLOG_APP_PASSWORD"),
+		)
+	defer client.Close()
+
+	// 1. Who are we
+	me, err := client.Users().Me().ContextEdit().Do()
+	require.NoError(t, err)
+	assert.Equal(t, os.Getenv("BLOG_USERNAME"), me.Username)
+	assert.True(t, me.HasRole(gowprest.RoleAdministrator))
+	assert.True(t, me.Can("manage_options"))
+	assert.NotEmpty(t, me.AvatarURLs["96"])
+	require.NotNil(t, me.RegisteredDate)
+	assert.True(t, me.RegisteredDate.HasOffset())
+
+	anonymous := gowprest.NewClient(blogUrl)
+	defer anonymous.Close()
+	_, err = anonymous.Users().Me().Do()
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_not_logged_in", wpError.Code)
+
+	// 2. Create users
+	username := faker.Username()
+	author, err := client.Users().Create(gowprest.UserData{
+		Username:  username,
+		Email:     username + "@example.com",
+		Password:  faker.Password(),
+		FirstName: "Ada",
+		LastName:  "Lovelace",
+		Roles:     []gowprest.Role{gowprest.RoleAuthor},
+	}).Do()
+	require.NoError(t, err)
+	assert.Equal(t, username, author.Username)
+	assert.Equal(t, []gowprest.Role{gowprest.RoleAuthor}, author.Roles)
+	assert.True(t, author.Roles[0].IsKnown())
+
+	subscriberName := faker.Username()
+	subscriber, err := client.Users().Create(gowprest.UserData{
+		Username: subscriberName,
+		Email:    subscriberName + "@example.com",
+		Password: faker.Password(),
+	}).Do()
+	require.NoError(t, err)
+	assert.Equal(t, []gowprest.Role{gowprest.RoleSubscriber}, subscriber.Roles)
+	defer client.Users().Delete(subscriber.ID).Reassign(me.ID).Do()
+
+	_, err = client.Users().Create(gowprest.UserData{
+		Username: username,
+		Email:    "other-" + username + "@example.com",
+		Password: faker.Password(),
+	}).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "existing_user_login", wpError.Code)
+
+	// 3. List with filters
+	users, err := client.Users().List().Roles(gowprest.RoleAuthor, gowprest.RoleEditor).Do()
+	require.NoError(t, err)
+	assert.Contains(t, userIDs(users), author.ID)
+	assert.NotContains(t, userIDs(users), subscriber.ID)
+
+	users, err = client.Users().List().WhoAuthors().Do()
+	require.NoError(t, err)
+	assert.Contains(t, userIDs(users), author.ID)
+	assert.NotContains(t, userIDs(users), subscriber.ID)
+
+	users, err = client.Users().List().Capabilities("edit_posts", "publish_posts").Do()
+	require.NoError(t, err)
+	assert.Contains(t, userIDs(users), author.ID)
+	assert.NotContains(t, userIDs(users), subscriber.ID)
+
+	users, err = client.Users().List().Slugs(author.Slug, subscriber.Slug).ContextEdit().Do()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{author.ID, subscriber.ID}, userIDs(users))
+
+	_, err = anonymous.Users().List().Roles(gowprest.RoleAuthor).Do()
+	assert.Error(t, err, "anonymous requests cannot filter by role")
+
+	// 4. Retrieve and update
+	retrieved, err := client.Users().Retrieve(author.ID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, author.ID, retrieved.ID)
+
+	updated, err := client.Users().Update(gowprest.UserData{
+		ID:          author.ID,
+		Name:        "Ada L.",
+		Description: "Analytical",
+		Roles:       []gowprest.Role{gowprest.RoleEditor},
+	}).Do()
+	require.NoError(t, err)
+	assert.Equal(t, "Ada L.", updated.Name)
+	assert.Equal(t, "Analytical", updated.Description)
+	assert.True(t, updated.HasRole(gowprest.RoleEditor))
+
+	_, err = client.Users().Update(gowprest.UserData{ID: author.ID, Username: "renamed"}).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_user_invalid_argument", wpError.Code)
+
+	// 5. Delete with reassign
+	post, err := client.Posts().Create(gowprest.PostData{Title: faker.Sentence(), Author: author.ID}).Do()
+	require.NoError(t, err)
+	defer client.Posts().Delete(post.ID).Force().Do()
+
+	_, err = client.Users().Delete(author.ID).Do()
+	require.ErrorIs(t, err, gowprest.ErrReassignRequired)
+
+	_, err = client.Users().Delete(author.ID).Reassign(author.ID).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_user_invalid_reassign", wpError.Code)
+
+	deleted, err := client.Users().Delete(author.ID).Reassign(me.ID).Do()
+	require.NoError(t, err)
+	assert.Equal(t, author.ID, deleted.ID)
+	assert.Equal(t, username, deleted.Username)
+
+	reassigned, err := client.Posts().Retrieve(post.ID).ContextEdit().Do()
+	require.NoError(t, err)
+	assert.Equal(t, me.ID, reassigned.Author)
+
+	_, err = client.Users().Retrieve(author.ID).Do()
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, "rest_user_invalid_id", wpError.Code)
+}
+
+func userIDs(users []gowprest.User) []int {
+	ids := []int{}
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
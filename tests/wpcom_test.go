@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raitucarp/gowprest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWPComServer stands in for public-api.wordpress.com and serves a single
+// site under the /wp/v2/sites/{site} layout.
+func newWPComServer(t *testing.T, token string) *httptest.Server {
+	routes := map[string]any{
+		"GET /wp/v2/sites/example.wordpress.com/posts":         []map[string]any{{"id": 1, "title": map[string]any{"rendered": "Hello"}}},
+		"GET /wp/v2/sites/example.wordpress.com/pages/2":       map[string]any{"id": 2},
+		"GET /wp/v2/sites/example.wordpress.com/comments":      []map[string]any{{"id": 3, "post": 1}},
+		"POST /wp/v2/sites/example.wordpress.com/categories":   map[string]any{"id": 4, "name": "News"},
+		"GET /wp/v2/sites/example.wordpress.com":               map[string]any{"name": "Example", "home": "https://example.wordpress.com"},
+		"POST /wp/v2/sites/example.wordpress.com/posts":        map[string]any{"id": 5},
+		"DELETE /wp/v2/sites/example.wordpress.com/posts/5":    map[string]any{"id": 5, "status": "trash"},
+		"DELETE /wp/v2/sites/example.wordpress.com/comments/3": map[string]any{"deleted": true, "previous": map[string]any{"id": 3}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]any{
+				"code":    "rest_cannot_create",
+				"message": "Sorry, you are not allowed to do that.",
+				"data":    map[string]any{"status": 401},
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+}
+
+func TestWordPressComRoutes(t *testing.T) {
+	server := newWPComServer(t, "oauth-token")
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL + "/wp/v2/sites/example.wordpress.com/").
+		WithBearerToken("oauth-token")
+	defer client.Close()
+
+	info, err := client.Discover()
+	require.NoError(t, err)
+	assert.Equal(t, "Example", info.Name)
+
+	posts, err := client.Posts().List().Do()
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, "Hello", posts[0].Title.Rendered)
+
+	page, err := client.Pages().Retrieve(2).Do()
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.ID)
+
+	comments, err := client.Comments().List().Do()
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+
+	category, err := client.Categories().Create(gowprest.CategoryData{Name: "News"}).Do()
+	require.NoError(t, err)
+	assert.Equal(t, 4, category.ID)
+
+	post, err := client.Posts().Create(gowprest.PostData{Title: "Hi"}).Do()
+	require.NoError(t, err)
+	assert.Equal(t, 5, post.ID)
+
+	_, err = client.Posts().Delete(5).Do()
+	require.NoError(t, err)
+
+	deleted, err := client.Comments().Delete(3).Force().Do()
+	require.NoError(t, err)
+	assert.Equal(t, 3, deleted.Previous.ID)
+}
+
+func TestWordPressComRequiresToken(t *testing.T) {
+	server := newWPComServer(t, "oauth-token")
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL + "/wp/v2/sites/example.wordpress.com").
+		WithBearerToken("wrong-token")
+	defer client.Close()
+
+	_, err := client.Categories().Create(gowprest.CategoryData{Name: "News"}).Do()
+	require.Error(t, err)
+
+	var wpError *gowprest.WPRestError
+	require.ErrorAs(t, err, &wpError)
+	assert.Equal(t, 401, wpError.Data.Status)
+}
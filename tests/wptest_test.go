@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	_ "github.com/joho/godotenv/autoload"
+	"github.com/raitucarp/gowprest"
+	"github.com/raitucarp/gowprest/wptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeServer(t *testing.T) {
+	server := wptest.NewServer()
+	defer server.Close()
+
+	client := gowprest.NewClient(server.URL).
+		WithBasicAuth(wptest.Username, wptest.Password)
+	defer client.Close()
+
+	t.Run("discovery", func(t *testing.T) {
+		info, err := client.Discover()
+
+		require.NoError(t, err)
+		assert.Equal(t, server.Name, info.Name)
+		assert.Equal(t, server.URL, info.Home)
+	})
+
+	t.Run("seeded content", func(t *testing.T) {
+		posts, err := client.Posts().List().Do()
+
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, "hello-world", posts[0].Slug)
+	})
+
+	t.Run("pagination headers", func(t *testing.T) {
+		for range 3 {
+			_, err := client.Posts().Create(gowprest.PostData{Title: "Paged", Status: gowprest.StatusPublished}).Do()
+			require.NoError(t, err)
+		}
+
+		resp, err := http.Get(server.URL + "/wp-json/wp/v2/posts?per_page=2")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, "4", resp.Header.Get("X-WP-Total"))
+		assert.Equal(t, "2", resp.Header.Get("X-WP-TotalPages"))
+		assert.Contains(t, resp.Header.Get("Link"), `rel="next"`)
+	})
+
+	t.Run("wrong application password", func(t *testing.T) {
+		wrong := gowprest.NewClient(server.URL).WithBasicAuth(wptest.Username, "wrong")
+		defer wrong.Close()
+
+		_, err := wrong.Posts().Create(gowprest.PostData{Title: "Denied"}).Do()
+
+		var wpErr *gowprest.WPRestError
+		require.ErrorAs(t, err, &wpErr)
+		assert.Equal(t, "incorrect_password", wpErr.Code)
+	})
+
+	t.Run("trash then force delete", func(t *testing.T) {
+		post, err := client.Posts().Create(gowprest.PostData{Title: "Disposable"}).Do()
+		require.NoError(t, err)
+
+		_, err = client.Posts().Delete(post.ID).Do()
+		require.NoError(t, err)
+
+		_, err = client.Posts().Delete(post.ID).Do()
+		var wpErr *gowprest.WPRestError
+		require.ErrorAs(t, err, &wpErr)
+		assert.Equal(t, "rest_already_trashed", wpErr.Code)
+
+		_, err = client.Posts().Delete(post.ID).Force().Do()
+		require.NoError(t, err)
+
+		_, err = client.Posts().Retrieve(post.ID).Do()
+		require.ErrorAs(t, err, &wpErr)
+		assert.Equal(t, "rest_post_invalid_id", wpErr.Code)
+	})
+}
@@ -1 +1,241 @@
-package gowprest
+package gowprest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// ErrThemeActivationUnsupported is returned by ActivateTheme when the site
+// has no route to activate themes, which is the case for WordPress core.
+var ErrThemeActivationUnsupported = errors.New("gowprest: the site does not allow activating themes through the REST API")
+
+type ThemeStatus string
+
+const (
+	ThemeActive   ThemeStatus = "active"
+	ThemeInactive ThemeStatus = "inactive"
+)
+
+// IsKnown reports whether s is active or inactive.
+func (s ThemeStatus) IsKnown() bool {
+	return s == ThemeActive || s == ThemeInactive
+}
+
+// ThemeSupports maps the features a theme declared with add_theme_support
+// to their arguments: true, false, or the list or object given with the
+// feature, such as the color palette.
+type ThemeSupports map[string]any
+
+// Has reports whether the theme supports feature.
+func (s ThemeSupports) Has(feature string) bool {
+	value, found := s[feature]
+	return found && value != nil && value != false
+}
+
+// ThemeTags are the tags from the theme's style.css header.
+type ThemeTags struct {
+	Raw      []string `json:"raw,omitempty"`
+	Rendered string   `json:"rendered"`
+}
+
+// Theme is an installed theme. Stylesheet identifies it; for a child theme
+// Template is the stylesheet of its parent.
+type Theme struct {
+	Stylesheet    string        `json:"stylesheet,omitempty"`
+	Template      string        `json:"template,omitempty"`
+	Name          *Object       `json:"name,omitempty"`
+	Description   *Object       `json:"description,omitempty"`
+	Author        *Object       `json:"author,omitempty"`
+	AuthorURI     *Object       `json:"author_uri,omitempty"`
+	ThemeURI      *Object       `json:"theme_uri,omitempty"`
+	Tags          *ThemeTags    `json:"tags,omitempty"`
+	Version       string        `json:"version,omitempty"`
+	RequiresPHP   string        `json:"requires_php,omitempty"`
+	RequiresWP    string        `json:"requires_wp,omitempty"`
+	Textdomain    string        `json:"textdomain,omitempty"`
+	Screenshot    string        `json:"screenshot,omitempty"`
+	Status        ThemeStatus   `json:"status,omitempty"`
+	ThemeSupports ThemeSupports `json:"theme_supports,omitempty"`
+	IsBlockTheme  bool          `json:"is_block_theme,omitempty"`
+	StylesheetURI string        `json:"stylesheet_uri,omitempty"`
+	TemplateURI   string        `json:"template_uri,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// IsChild reports whether the theme is a child theme.
+func (t Theme) IsChild() bool {
+	return t.Template != "" && t.Template != t.Stylesheet
+}
+
+// Themes lists the installed themes. Every request is authenticated, as
+// only users who can switch themes see more than the active one.
+type Themes struct {
+	client *RestClient
+}
+
+func (c *RestClient) Themes() *Themes {
+	return &Themes{client: c}
+}
+
+type ListThemes struct {
+	endpoint  string
+	client    *RestClient
+	arguments url.Values
+}
+
+func (api *ListThemes) Clone() *ListThemes {
+	clone := *api
+	clone.arguments = cloneValues(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListThemes) With(options ...RequestOption) *ListThemes {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Themes) List() *ListThemes {
+	return &ListThemes{
+		endpoint:  "/wp/v2/themes",
+		client:    api.client,
+		arguments: url.Values{},
+	}
+}
+
+// StatusIn limits the result to themes with any of the given statuses.
+func (api *ListThemes) StatusIn(statuses ...ThemeStatus) *ListThemes {
+	api = api.Clone()
+	values := []string{}
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+	setList(api.arguments, "status", values...)
+	return api
+}
+
+func (api *ListThemes) Do() (themes []Theme, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&themes).
+		SetQueryParamsFromValues(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return themes, &wpError
+	}
+
+	return
+}
+
+type RetrieveTheme struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *RetrieveTheme) Clone() *RetrieveTheme {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveTheme) With(options ...RequestOption) *RetrieveTheme {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Themes) Retrieve(stylesheet string) *RetrieveTheme {
+	return &RetrieveTheme{
+		endpoint: "/wp/v2/themes/" + url.PathEscape(stylesheet),
+		client:   api.client,
+	}
+}
+
+func (api *RetrieveTheme) Do() (theme *Theme, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&theme).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return theme, &wpError
+	}
+
+	return
+}
+
+type ActivateTheme struct {
+	endpoint string
+	client   *RestClient
+}
+
+func (api *ActivateTheme) Clone() *ActivateTheme {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ActivateTheme) With(options ...RequestOption) *ActivateTheme {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Activate switches the site to the theme by setting its status to active.
+// WordPress core only exposes themes for reading, so this works only where
+// a host or plugin adds the write route; elsewhere Do returns
+// ErrThemeActivationUnsupported.
+func (api *Themes) Activate(stylesheet string) *ActivateTheme {
+	return &ActivateTheme{
+		endpoint: "/wp/v2/themes/" + url.PathEscape(stylesheet),
+		client:   api.client,
+	}
+}
+
+func (api *ActivateTheme) Do() (theme Theme, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&theme).
+		SetBody(map[string]any{"status": ThemeActive}).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		if wpError.Code == "rest_no_route" {
+			return theme, errors.Join(ErrThemeActivationUnsupported, &wpError)
+		}
+		return theme, &wpError
+	}
+
+	return
+}
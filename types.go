@@ -1 +1,194 @@
-package gowprest
+package gowprest
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+type PostTypeVisibility struct {
+	ShowUI         bool `json:"show_ui"`
+	ShowInNavMenus bool `json:"show_in_nav_menus"`
+}
+
+type PostType struct {
+	Capabilities  map[string]string  `json:"capabilities,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Hierarchical  bool               `json:"hierarchical,omitempty"`
+	Viewable      bool               `json:"viewable,omitempty"`
+	Labels        map[string]string  `json:"labels,omitempty"`
+	Name          string             `json:"name,omitempty"`
+	Slug          string             `json:"slug,omitempty"`
+	Supports      map[string]bool    `json:"supports,omitempty"`
+	HasArchive    any                `json:"has_archive,omitempty"`
+	Taxonomies    []string           `json:"taxonomies,omitempty"`
+	RestBase      string             `json:"rest_base,omitempty"`
+	RestNamespace string             `json:"rest_namespace,omitempty"`
+	Visibility    PostTypeVisibility `json:"visibility,omitempty"`
+	Icon          string             `json:"icon,omitempty"`
+	Template      []any              `json:"template,omitempty"`
+	TemplateLock  any                `json:"template_lock,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+type Types struct {
+	client *RestClient
+}
+
+func (c *RestClient) Types() *Types {
+	return &Types{client: c}
+}
+
+type ListTypes struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *ListTypes) Clone() *ListTypes {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListTypes) With(options ...RequestOption) *ListTypes {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Types) List() *ListTypes {
+	return &ListTypes{
+		endpoint:  "/wp/v2/types",
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *ListTypes) ContextView() *ListTypes {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *ListTypes) ContextEdit() *ListTypes {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *ListTypes) ContextEmbed() *ListTypes {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *ListTypes) Do() (types map[string]PostType, err error) {
+	endpoint := api.client.url(api.endpoint)
+
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&types).
+		SetQueryParams(api.arguments).
+		Get(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return types, &wpError
+	}
+
+	return
+}
+
+type RetrieveType struct {
+	endpoint  string
+	client    *RestClient
+	arguments map[string]string
+}
+
+func (api *RetrieveType) Clone() *RetrieveType {
+	clone := *api
+	clone.arguments = maps.Clone(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveType) With(options ...RequestOption) *RetrieveType {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Types) Retrieve(postType string) *RetrieveType {
+	return &RetrieveType{
+		endpoint:  "/wp/v2/types/" + postType,
+		client:    api.client,
+		arguments: make(map[string]string),
+	}
+}
+
+func (api *RetrieveType) ContextView() *RetrieveType {
+	api = api.Clone()
+	api.arguments["context"] = "view"
+	return api
+}
+
+func (api *RetrieveType) ContextEdit() *RetrieveType {
+	api = api.Clone()
+	api.arguments["context"] = "edit"
+	return api
+}
+
+func (api *RetrieveType) ContextEmbed() *RetrieveType {
+	api = api.Clone()
+	api.arguments["context"] = "embed"
+	return api
+}
+
+func (api *RetrieveType) Do() (postType *PostType, err error) {
+	endpoint := api.client.url(api.endpoint)
+
+	restyClient := api.client.request()
+	if api.arguments["context"] == "edit" {
+		api.client.authenticate(restyClient)
+	}
+
+	resp, err := restyClient.
+		SetHeader("Accept", "application/json").
+		SetResult(&postType).
+		SetQueryParams(api.arguments).
+		Get(endpoint)
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return postType, &wpError
+	}
+
+	return
+}
@@ -0,0 +1,46 @@
+package gowprest
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ErrAmbiguousSlug is returned by the Upsert builders when more than one
+// item matches the slug, which WordPress allows for drafts.
+var ErrAmbiguousSlug = errors.New("gowprest: more than one item matches the slug")
+
+var errSlugRequired = errors.New("gowprest: upsert requires a slug")
+
+// trashedSuffix is appended by WordPress to the slug of trashed posts and
+// pages, freeing the slug for new ones.
+const trashedSuffix = "__trashed"
+
+// findBySlug lists the items of route matching query. The request is
+// authenticated and made in the edit context, so drafts and private items
+// are found as well.
+func findBySlug[T any](client *RestClient, route string, query map[string]string) (items []T, err error) {
+	resp, err := client.authenticate(client.request()).
+		SetHeader("Accept", "application/json").
+		SetQueryParams(query).
+		SetQueryParam("context", "edit").
+		SetQueryParam("per_page", "2").
+		Get(client.url(route))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+
+		if err != nil {
+			return
+		}
+
+		return items, &wpError
+	}
+
+	err = unmarshal(resp.Bytes(), &items)
+	return
+}
@@ -1 +1,559 @@
-package gowprest
+package gowprest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// Role is a user role. Plugins can register roles beyond the ones below.
+type Role string
+
+const (
+	RoleAdministrator Role = "administrator"
+	RoleEditor        Role = "editor"
+	RoleAuthor        Role = "author"
+	RoleContributor   Role = "contributor"
+	RoleSubscriber    Role = "subscriber"
+)
+
+// IsKnown reports whether r is one of the roles of WordPress core.
+func (r Role) IsKnown() bool {
+	switch r {
+	case RoleAdministrator, RoleEditor, RoleAuthor, RoleContributor, RoleSubscriber:
+		return true
+	}
+	return false
+}
+
+// User is a user of the site. Username, the names, Email, Locale, Nickname,
+// RegisteredDate, Roles and the capabilities are only sent in the edit
+// context.
+type User struct {
+	ID                int               `json:"id,omitempty"`
+	Username          string            `json:"username,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	FirstName         string            `json:"first_name,omitempty"`
+	LastName          string            `json:"last_name,omitempty"`
+	Email             string            `json:"email,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	Link              string            `json:"link,omitempty"`
+	Locale            string            `json:"locale,omitempty"`
+	Nickname          string            `json:"nickname,omitempty"`
+	Slug              string            `json:"slug,omitempty"`
+	RegisteredDate    *Date             `json:"registered_date,omitempty"`
+	Roles             []Role            `json:"roles,omitempty"`
+	Capabilities      map[string]bool   `json:"capabilities,omitempty"`
+	ExtraCapabilities map[string]bool   `json:"extra_capabilities,omitempty"`
+	AvatarURLs        map[string]string `json:"avatar_urls,omitempty"`
+	Meta              any               `json:"meta,omitempty"`
+
+	Extras Extras `json:"-"`
+}
+
+// HasRole reports whether the user has role.
+func (u User) HasRole(role Role) bool {
+	return slices.Contains(u.Roles, role)
+}
+
+// Can reports whether the user has capability, as listed in the edit context.
+func (u User) Can(capability string) bool {
+	return u.Capabilities[capability]
+}
+
+type UserData struct {
+	ID          int    `json:"id,omitempty"`
+	Username    string `json:"username,omitempty"`
+	Name        string `json:"name,omitempty"`
+	FirstName   string `json:"first_name,omitempty"`
+	LastName    string `json:"last_name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	Nickname    string `json:"nickname,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	Roles       []Role `json:"roles,omitempty"`
+	Password    string `json:"password,omitempty"`
+	Meta        any    `json:"meta,omitempty"`
+}
+
+// Users manages the site's users. Every request is authenticated, since
+// anonymous requests only see users who published a post.
+type Users struct {
+	client *RestClient
+}
+
+func (c *RestClient) Users() *Users {
+	return &Users{client: c}
+}
+
+type ListUsers struct {
+	endpoint  string
+	client    *RestClient
+	arguments url.Values
+}
+
+func (api *ListUsers) Clone() *ListUsers {
+	clone := *api
+	clone.arguments = cloneValues(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *ListUsers) With(options ...RequestOption) *ListUsers {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Users) List() *ListUsers {
+	return &ListUsers{
+		endpoint:  "/wp/v2/users",
+		client:    api.client,
+		arguments: url.Values{},
+	}
+}
+
+func (api *ListUsers) ContextView() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("context", "view")
+	return api
+}
+
+func (api *ListUsers) ContextEdit() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("context", "edit")
+	return api
+}
+
+func (api *ListUsers) ContextEmbed() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("context", "embed")
+	return api
+}
+
+func (api *ListUsers) Page(page int) *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("page", strconv.Itoa(page))
+	return api
+}
+
+func (api *ListUsers) PerPage(perPage int) *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("per_page", strconv.Itoa(perPage))
+	return api
+}
+
+func (api *ListUsers) Search(query string) *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("search", query)
+	return api
+}
+
+func (api *ListUsers) Exclude(excludeIDs ...int) *ListUsers {
+	api = api.Clone()
+	setIDs(api.arguments, "exclude", excludeIDs...)
+	return api
+}
+
+func (api *ListUsers) Include(includeIDs ...int) *ListUsers {
+	api = api.Clone()
+	setIDs(api.arguments, "include", includeIDs...)
+	return api
+}
+
+func (api *ListUsers) Offset(offset int) *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("offset", strconv.Itoa(offset))
+	return api
+}
+
+func (api *ListUsers) OrderAsc() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("order", "asc")
+	return api
+}
+
+func (api *ListUsers) OrderDesc() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("order", "desc")
+	return api
+}
+
+func (api *ListUsers) OrderById() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "id")
+	return api
+}
+
+func (api *ListUsers) OrderByInclude() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "include")
+	return api
+}
+
+func (api *ListUsers) OrderByName() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "name")
+	return api
+}
+
+func (api *ListUsers) OrderByRegisteredDate() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "registered_date")
+	return api
+}
+
+func (api *ListUsers) OrderBySlug() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "slug")
+	return api
+}
+
+func (api *ListUsers) OrderByIncludeSlug() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "include_slugs")
+	return api
+}
+
+func (api *ListUsers) OrderByEmail() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "email")
+	return api
+}
+
+func (api *ListUsers) OrderByURL() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("orderby", "url")
+	return api
+}
+
+func (api *ListUsers) Slugs(slugs ...string) *ListUsers {
+	api = api.Clone()
+	setList(api.arguments, "slug", slugs...)
+	return api
+}
+
+// Roles limits the result to users with any of the given roles.
+func (api *ListUsers) Roles(roles ...Role) *ListUsers {
+	api = api.Clone()
+	values := []string{}
+	for _, role := range roles {
+		values = append(values, string(role))
+	}
+	setList(api.arguments, "roles", values...)
+	return api
+}
+
+// Capabilities limits the result to users with every given capability.
+func (api *ListUsers) Capabilities(capabilities ...string) *ListUsers {
+	api = api.Clone()
+	setList(api.arguments, "capabilities", capabilities...)
+	return api
+}
+
+// WhoAuthors limits the result to users who can author posts.
+func (api *ListUsers) WhoAuthors() *ListUsers {
+	api = api.Clone()
+	api.arguments.Set("who", "authors")
+	return api
+}
+
+// HasPublishedPosts limits the result to users who published a post of any
+// of postTypes, or of any public type when none is given.
+func (api *ListUsers) HasPublishedPosts(postTypes ...string) *ListUsers {
+	api = api.Clone()
+	if len(postTypes) == 0 {
+		setList(api.arguments, "has_published_posts")
+		api.arguments.Set("has_published_posts", "true")
+		return api
+	}
+	setList(api.arguments, "has_published_posts", postTypes...)
+	return api
+}
+
+func (api *ListUsers) Do() (users []User, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&users).
+		SetQueryParamsFromValues(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return users, &wpError
+	}
+
+	return
+}
+
+type RetrieveUser struct {
+	endpoint  string
+	client    *RestClient
+	arguments url.Values
+}
+
+func (api *RetrieveUser) Clone() *RetrieveUser {
+	clone := *api
+	clone.arguments = cloneValues(api.arguments)
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *RetrieveUser) With(options ...RequestOption) *RetrieveUser {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Users) Retrieve(userID int) *RetrieveUser {
+	return &RetrieveUser{
+		endpoint:  "/wp/v2/users/" + strconv.Itoa(userID),
+		client:    api.client,
+		arguments: url.Values{},
+	}
+}
+
+// Me retrieves the user the client's credentials belong to.
+func (api *Users) Me() *RetrieveUser {
+	return &RetrieveUser{
+		endpoint:  "/wp/v2/users/me",
+		client:    api.client,
+		arguments: url.Values{},
+	}
+}
+
+func (api *RetrieveUser) ContextView() *RetrieveUser {
+	api = api.Clone()
+	api.arguments.Set("context", "view")
+	return api
+}
+
+func (api *RetrieveUser) ContextEdit() *RetrieveUser {
+	api = api.Clone()
+	api.arguments.Set("context", "edit")
+	return api
+}
+
+func (api *RetrieveUser) ContextEmbed() *RetrieveUser {
+	api = api.Clone()
+	api.arguments.Set("context", "embed")
+	return api
+}
+
+func (api *RetrieveUser) Do() (user *User, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Accept", "application/json").
+		SetResult(&user).
+		SetQueryParamsFromValues(api.arguments).
+		Get(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return user, &wpError
+	}
+
+	return
+}
+
+type CreateUser struct {
+	endpoint string
+	client   *RestClient
+	user     UserData
+}
+
+func (api *CreateUser) Clone() *CreateUser {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *CreateUser) With(options ...RequestOption) *CreateUser {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Create registers a user; Username, Email and Password are required.
+func (api *Users) Create(user UserData) *CreateUser {
+	return &CreateUser{
+		endpoint: "/wp/v2/users",
+		client:   api.client,
+		user:     user,
+	}
+}
+
+func (api *CreateUser) Do() (user User, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&user).
+		SetBody(api.user).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return user, &wpError
+	}
+
+	return
+}
+
+type UpdateUser struct {
+	endpoint string
+	client   *RestClient
+	user     UserData
+}
+
+func (api *UpdateUser) Clone() *UpdateUser {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *UpdateUser) With(options ...RequestOption) *UpdateUser {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+// Update changes the user user.ID. The username cannot be changed.
+func (api *Users) Update(user UserData) *UpdateUser {
+	return &UpdateUser{
+		endpoint: "/wp/v2/users/" + strconv.Itoa(user.ID),
+		client:   api.client,
+		user:     user,
+	}
+}
+
+func (api *UpdateUser) Do() (user User, err error) {
+	resp, err := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetResult(&user).
+		SetBody(api.user).
+		Post(api.client.url(api.endpoint))
+
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return user, &wpError
+	}
+
+	return
+}
+
+// ErrReassignRequired is returned by DeleteUser.Do when neither Reassign
+// nor DeleteContent was called. Nothing is sent to the site.
+var ErrReassignRequired = errors.New("gowprest: deleting a user requires Reassign or DeleteContent")
+
+// DeleteUser deletes a user. WordPress requires the user's content to be
+// either reassigned or deleted with it, so one of Reassign and DeleteContent
+// must be called before Do.
+type DeleteUser struct {
+	endpoint string
+	client   *RestClient
+	reassign string
+}
+
+func (api *DeleteUser) Clone() *DeleteUser {
+	clone := *api
+	return &clone
+}
+
+// With applies options to the requests of this builder only.
+func (api *DeleteUser) With(options ...RequestOption) *DeleteUser {
+	api = api.Clone()
+	api.client = api.client.With(options...)
+	return api
+}
+
+func (api *Users) Delete(userID int) *DeleteUser {
+	return &DeleteUser{
+		endpoint: "/wp/v2/users/" + strconv.Itoa(userID),
+		client:   api.client,
+	}
+}
+
+// Reassign gives the user's posts and links to the user userID.
+func (api *DeleteUser) Reassign(userID int) *DeleteUser {
+	api = api.Clone()
+	api.reassign = strconv.Itoa(userID)
+	return api
+}
+
+// DeleteContent deletes the user's posts and links along with the user.
+func (api *DeleteUser) DeleteContent() *DeleteUser {
+	api = api.Clone()
+	api.reassign = "false"
+	return api
+}
+
+// Do deletes the user, which cannot be trashed, and returns it as it was.
+func (api *DeleteUser) Do() (user User, err error) {
+	if api.reassign == "" {
+		return user, ErrReassignRequired
+	}
+
+	req := api.client.authenticate(api.client.request()).
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("force", "true").
+		SetQueryParam("reassign", api.reassign)
+
+	resp, err := req.Delete(api.client.url(api.endpoint))
+	if err != nil {
+		return
+	}
+
+	if resp.IsError() {
+		var wpError WPRestError
+		err = json.Unmarshal(resp.Bytes(), &wpError)
+		if err != nil {
+			return
+		}
+		return user, &wpError
+	}
+
+	var nested struct {
+		Deleted  bool `json:"deleted"`
+		Previous User `json:"previous"`
+	}
+	err = unmarshal(resp.Bytes(), &nested)
+	if err != nil {
+		return
+	}
+	return nested.Previous, nil
+}
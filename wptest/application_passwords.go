@@ -0,0 +1,166 @@
+package wptest
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+type appPassword struct {
+	uuid     string
+	appID    string
+	name     string
+	password string
+	created  time.Time
+	lastUsed *time.Time
+	lastIP   string
+}
+
+// appPassword returns the user's application password matching password,
+// which WordPress compares with the spaces of its display form removed.
+func (u *user) appPassword(password string) *appPassword {
+	password = strings.ReplaceAll(password, " ", "")
+	for _, candidate := range u.appPasswords {
+		if strings.ReplaceAll(candidate.password, " ", "") == password {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// newAppPassword generates a password in the chunked form WordPress shows
+// once on creation.
+func newAppPassword() string {
+	text := rand.Text()
+	chunks := []string{}
+	for i := 0; i < 24; i += 4 {
+		chunks = append(chunks, text[i:i+4])
+	}
+	return strings.Join(chunks, " ")
+}
+
+func errAppPasswordNotFound() *apiError {
+	return newError(http.StatusNotFound, "rest_application_password_not_found", "Application password not found.")
+}
+
+func (s *Server) routeAppPasswords(req *request, u *user, rest []string) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_manage_application_passwords", "Sorry, you are not allowed to manage application passwords for this user.")
+	}
+
+	if len(rest) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			body := []map[string]any{}
+			for _, item := range u.appPasswords {
+				body = append(body, renderAppPassword(item))
+			}
+			return ok(body), nil
+		case http.MethodPost:
+			return s.createAppPassword(req, u)
+		case http.MethodDelete:
+			count := len(u.appPasswords)
+			u.appPasswords = nil
+			return ok(map[string]any{"deleted": true, "count": count}), nil
+		}
+		return nil, errNoRoute()
+	}
+
+	if len(rest) > 1 {
+		return nil, errNoRoute()
+	}
+
+	if rest[0] == "introspect" {
+		if req.method != http.MethodGet {
+			return nil, errNoRoute()
+		}
+		if req.user != u || req.appPassword == nil {
+			return nil, newError(http.StatusNotFound, "rest_no_authenticated_app_password", "Cannot introspect application password.")
+		}
+		return ok(renderAppPassword(req.appPassword)), nil
+	}
+
+	index := slices.IndexFunc(u.appPasswords, func(candidate *appPassword) bool {
+		return candidate.uuid == rest[0]
+	})
+	if index < 0 {
+		return nil, errAppPasswordNotFound()
+	}
+	item := u.appPasswords[index]
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(renderAppPassword(item)), nil
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		if req.has("name") {
+			name := strings.TrimSpace(req.str("name"))
+			if name == "" {
+				return nil, errInvalidParam("name")
+			}
+			item.name = name
+		}
+		return ok(renderAppPassword(item)), nil
+	case http.MethodDelete:
+		u.appPasswords = slices.Delete(u.appPasswords, index, index+1)
+		return ok(map[string]any{"deleted": true, "previous": renderAppPassword(item)}), nil
+	}
+
+	return nil, errNoRoute()
+}
+
+func (s *Server) createAppPassword(req *request, u *user) (*response, *apiError) {
+	if !req.has("name") {
+		return nil, errMissingParam("name")
+	}
+
+	name := strings.TrimSpace(req.str("name"))
+	if name == "" {
+		return nil, newError(http.StatusBadRequest, "application_password_empty_name", "An application name is required to create an application password.")
+	}
+	for _, other := range u.appPasswords {
+		if other.name == name {
+			return nil, newError(http.StatusConflict, "application_password_duplicate_name", "Each application name should be unique.")
+		}
+	}
+
+	item := &appPassword{
+		uuid:     newUUID(),
+		appID:    req.str("app_id"),
+		name:     name,
+		password: newAppPassword(),
+		created:  time.Now().UTC().Truncate(time.Second),
+	}
+	u.appPasswords = append(u.appPasswords, item)
+
+	body := renderAppPassword(item)
+	body["password"] = item.password
+	return created(body), nil
+}
+
+func renderAppPassword(item *appPassword) map[string]any {
+	var lastUsed, lastIP any
+	if item.lastUsed != nil {
+		lastUsed = item.lastUsed.Format(time.RFC3339)
+		lastIP = item.lastIP
+	}
+
+	return map[string]any{
+		"uuid":      item.uuid,
+		"app_id":    item.appID,
+		"name":      item.name,
+		"created":   item.created.Format(time.RFC3339),
+		"last_used": lastUsed,
+		"last_ip":   lastIP,
+	}
+}
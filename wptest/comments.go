@@ -0,0 +1,405 @@
+package wptest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type comment struct {
+	id              int
+	post            int
+	parent          int
+	author          int
+	authorName      string
+	authorEmail     string
+	authorURL       string
+	authorIP        string
+	authorUserAgent string
+	content         string
+	date            time.Time
+	status          string
+	commentType     string
+	meta            map[string]any
+}
+
+// commentStatuses maps the statuses accepted by the API to the ones stored
+// and reported back.
+var commentStatuses = map[string]string{
+	"approve":  "approved",
+	"approved": "approved",
+	"1":        "approved",
+	"hold":     "hold",
+	"0":        "hold",
+	"spam":     "spam",
+	"trash":    "trash",
+}
+
+func errInvalidCommentID() *apiError {
+	return newError(http.StatusNotFound, "rest_comment_invalid_id", "Invalid comment ID.")
+}
+
+func (s *Server) routeComments(req *request, rest []string) (*response, *apiError) {
+	if len(rest) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return s.listComments(req)
+		case http.MethodPost:
+			return s.createComment(req)
+		}
+		return nil, errNoRoute()
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil || len(rest) > 1 {
+		return nil, errNoRoute()
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return s.retrieveComment(req, id)
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return s.updateComment(req, id)
+	case http.MethodDelete:
+		return s.deleteComment(req, id)
+	}
+
+	return nil, errNoRoute()
+}
+
+func (s *Server) listComments(req *request) (*response, *apiError) {
+	context := req.context()
+	if context == "edit" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_forbidden_context", "Sorry, you are not allowed to edit comments.")
+	}
+
+	status := "approve"
+	if req.has("status") {
+		status = req.str("status")
+	}
+	if status != "approve" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_forbidden_param", "Query parameter not permitted: status")
+	}
+
+	search := strings.ToLower(req.str("search"))
+	posts := req.ints("post")
+	parents := req.ints("parent")
+	parentsExclude := req.ints("parent_exclude")
+	authors := req.ints("author")
+	authorsExclude := req.ints("author_exclude")
+	include := req.ints("include")
+	exclude := req.ints("exclude")
+	commentType := req.str("type")
+	if commentType == "" {
+		commentType = "comment"
+	}
+
+	items := []*comment{}
+	for _, c := range s.comments {
+		switch status {
+		case "any", "all":
+			if c.status == "trash" || c.status == "spam" {
+				continue
+			}
+		default:
+			if commentStatuses[status] != c.status {
+				continue
+			}
+		}
+		if search != "" && !strings.Contains(strings.ToLower(c.content+" "+c.authorName), search) {
+			continue
+		}
+		if len(posts) > 0 && !slices.Contains(posts, c.post) {
+			continue
+		}
+		if len(parents) > 0 && !slices.Contains(parents, c.parent) {
+			continue
+		}
+		if slices.Contains(parentsExclude, c.parent) {
+			continue
+		}
+		if len(authors) > 0 && !slices.Contains(authors, c.author) {
+			continue
+		}
+		if slices.Contains(authorsExclude, c.author) {
+			continue
+		}
+		if len(include) > 0 && !slices.Contains(include, c.id) {
+			continue
+		}
+		if slices.Contains(exclude, c.id) {
+			continue
+		}
+		if email := req.str("author_email"); email != "" && c.authorEmail != email {
+			continue
+		}
+		if c.typeName() != commentType {
+			continue
+		}
+		items = append(items, c)
+	}
+
+	if err := order(req, items, "date_gmt", map[string]func(a, b *comment) int{
+		"date":     func(a, b *comment) int { return cmp.Or(compareTimes(a.date, b.date), cmp.Compare(a.id, b.id)) },
+		"date_gmt": func(a, b *comment) int { return cmp.Or(compareTimes(a.date, b.date), cmp.Compare(a.id, b.id)) },
+		"id":       func(a, b *comment) int { return cmp.Compare(a.id, b.id) },
+		"include":  func(a, b *comment) int { return cmp.Compare(indexOf(include, a.id), indexOf(include, b.id)) },
+		"post":     func(a, b *comment) int { return cmp.Compare(a.post, b.post) },
+		"parent":   func(a, b *comment) int { return cmp.Compare(a.parent, b.parent) },
+		"type":     func(a, b *comment) int { return strings.Compare(a.typeName(), b.typeName()) },
+	}); err != nil {
+		return nil, err
+	}
+
+	page, headers, apiErr := paginate(req, items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	body := []map[string]any{}
+	for _, c := range page {
+		body = append(body, s.renderComment(c, context))
+	}
+
+	return &response{status: http.StatusOK, headers: headers, body: body}, nil
+}
+
+func (s *Server) retrieveComment(req *request, id int) (*response, *apiError) {
+	c, found := s.comments[id]
+	if !found {
+		return nil, errInvalidCommentID()
+	}
+
+	context := req.context()
+	if context == "edit" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_forbidden_context", "Sorry, you are not allowed to edit comments.")
+	}
+
+	if c.status != "approved" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_read", "Sorry, you are not allowed to read this comment.")
+	}
+
+	return ok(s.renderComment(c, context)), nil
+}
+
+func (s *Server) createComment(req *request) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_comment_login_required", "Sorry, you must be logged in to comment.")
+	}
+
+	if req.has("id") {
+		return nil, newError(http.StatusBadRequest, "rest_comment_exists", "Cannot create existing comment.")
+	}
+
+	c := &comment{
+		author:      req.user.id,
+		authorName:  req.user.name,
+		authorEmail: req.user.email,
+		date:        time.Now().UTC().Truncate(time.Second),
+		status:      "approved",
+		meta:        map[string]any{},
+	}
+
+	if err := s.applyComment(req, c); err != nil {
+		return nil, err
+	}
+
+	p := s.posts[c.post]
+	if c.post == 0 || p == nil || p.postType == "revision" {
+		return nil, newError(http.StatusForbidden, "rest_comment_invalid_post_id", "Sorry, you are not allowed to create this comment without a post.")
+	}
+	if p.status == "draft" {
+		return nil, newError(http.StatusForbidden, "rest_comment_draft_post", "Sorry, you are not allowed to create a comment on this post.")
+	}
+	if p.status == "trash" {
+		return nil, newError(http.StatusForbidden, "rest_comment_trash_post", "Sorry, you are not allowed to create a comment on this post.")
+	}
+
+	if strings.TrimSpace(c.content) == "" {
+		return nil, newError(http.StatusBadRequest, "rest_comment_content_invalid", "Invalid comment content.")
+	}
+
+	for _, other := range s.comments {
+		if other.post == c.post && other.content == c.content && other.authorName == c.authorName {
+			return nil, newError(http.StatusConflict, "comment_duplicate", "Duplicate comment detected; it looks as though you&#8217;ve already said that!")
+		}
+	}
+
+	c.id = s.nextID()
+	s.comments[c.id] = c
+
+	return created(s.renderComment(c, "edit")), nil
+}
+
+func (s *Server) updateComment(req *request, id int) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_edit", "Sorry, you are not allowed to edit this comment.")
+	}
+
+	c, found := s.comments[id]
+	if !found {
+		return nil, errInvalidCommentID()
+	}
+
+	updated := *c
+	if err := s.applyComment(req, &updated); err != nil {
+		return nil, err
+	}
+
+	if req.has("post") && s.posts[updated.post] == nil {
+		return nil, newError(http.StatusForbidden, "rest_comment_invalid_post_id", "Invalid post ID.")
+	}
+
+	*c = updated
+	return ok(s.renderComment(c, "edit")), nil
+}
+
+func (s *Server) deleteComment(req *request, id int) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_delete", "Sorry, you are not allowed to delete this comment.")
+	}
+
+	c, found := s.comments[id]
+	if !found {
+		return nil, errInvalidCommentID()
+	}
+
+	if req.bool("force") {
+		previous := s.renderComment(c, "edit")
+		delete(s.comments, c.id)
+		return ok(map[string]any{"deleted": true, "previous": previous}), nil
+	}
+
+	if c.status == "trash" {
+		return nil, newError(http.StatusGone, "rest_already_trashed", "The comment has already been trashed.")
+	}
+
+	c.status = "trash"
+	return ok(s.renderComment(c, "edit")), nil
+}
+
+func (s *Server) applyComment(req *request, c *comment) *apiError {
+	if req.has("post") {
+		c.post = req.int("post")
+	}
+	if req.has("parent") {
+		parent := req.int("parent")
+		if parent != 0 {
+			if other, found := s.comments[parent]; !found || (c.post != 0 && other.post != c.post) {
+				return newError(http.StatusBadRequest, "rest_comment_invalid_parent", "Invalid comment parent ID.")
+			}
+		}
+		c.parent = parent
+	}
+	if req.has("author") {
+		author := req.int("author")
+		if author != 0 && !s.userExists(author) {
+			return newError(http.StatusBadRequest, "rest_comment_author_invalid", "Invalid comment author ID.")
+		}
+		c.author = author
+	}
+	if req.has("author_name") {
+		c.authorName = req.str("author_name")
+	}
+	if req.has("author_email") {
+		email := req.str("author_email")
+		if email != "" && !strings.Contains(email, "@") {
+			return errInvalidParam("author_email")
+		}
+		c.authorEmail = email
+	}
+	if req.has("author_url") {
+		c.authorURL = req.str("author_url")
+	}
+	if req.has("author_ip") {
+		c.authorIP = req.str("author_ip")
+	}
+	if req.has("author_user_agent") {
+		c.authorUserAgent = req.str("author_user_agent")
+	}
+	if req.has("content") {
+		c.content = rawValue(req, "content")
+	}
+	if req.has("date_gmt") {
+		t, valid := parseDate(req.str("date_gmt"), time.UTC)
+		if !valid {
+			return errInvalidParam("date_gmt")
+		}
+		c.date = t
+	} else if req.has("date") {
+		t, valid := parseDate(req.str("date"), s.Location)
+		if !valid {
+			return errInvalidParam("date")
+		}
+		c.date = t
+	}
+	if req.has("status") {
+		status, known := commentStatuses[req.str("status")]
+		if !known {
+			return errInvalidParam("status")
+		}
+		c.status = status
+	}
+	if req.has("meta") {
+		meta, valid := req.body["meta"].(map[string]any)
+		if !valid {
+			return errInvalidParam("meta")
+		}
+		if c.meta == nil {
+			c.meta = map[string]any{}
+		}
+		for key, value := range meta {
+			if value == nil {
+				delete(c.meta, key)
+				continue
+			}
+			c.meta[key] = value
+		}
+	}
+	return nil
+}
+
+func (c *comment) typeName() string {
+	if c.commentType == "" {
+		return "comment"
+	}
+	return c.commentType
+}
+
+func (s *Server) renderComment(c *comment, context string) map[string]any {
+	content := map[string]any{"rendered": autop(c.content)}
+
+	meta := map[string]any{}
+	for key, value := range c.meta {
+		meta[key] = value
+	}
+
+	data := map[string]any{
+		"id":                 c.id,
+		"post":               c.post,
+		"parent":             c.parent,
+		"author":             c.author,
+		"author_name":        c.authorName,
+		"author_url":         c.authorURL,
+		"date":               s.localDate(c.date),
+		"date_gmt":           formatDate(c.date),
+		"content":            content,
+		"link":               s.URL + "/?p=" + strconv.Itoa(c.post) + "#comment-" + strconv.Itoa(c.id),
+		"status":             c.status,
+		"type":               c.typeName(),
+		"author_avatar_urls": avatarURLs(c.authorEmail),
+		"meta":               meta,
+	}
+
+	if context == "edit" {
+		content["raw"] = c.content
+		data["author_email"] = c.authorEmail
+		data["author_ip"] = c.authorIP
+		data["author_user_agent"] = c.authorUserAgent
+	}
+
+	return s.addFields("comment", c.id, data)
+}
@@ -0,0 +1,584 @@
+package wptest
+
+import (
+	"bytes"
+	"cmp"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// attachment is an uploaded file with the attachment post describing it.
+type attachment struct {
+	id          int
+	author      int
+	post        int
+	status      string
+	slug        string
+	title       string
+	caption     string
+	description string
+	altText     string
+	date        time.Time
+	modified    time.Time
+	file        string
+	mimeType    string
+	data        []byte
+	width       int
+	height      int
+	meta        map[string]any
+}
+
+// uploadTypes maps the file extensions the fake accepts to their MIME type,
+// a subset of get_allowed_mime_types.
+var uploadTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".jpe":  "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".pdf":  "application/pdf",
+	".txt":  "text/plain",
+	".csv":  "text/csv",
+	".mp3":  "audio/mpeg",
+	".mp4":  "video/mp4",
+	".zip":  "application/zip",
+}
+
+// imageSizes are the intermediate sizes WordPress registers by default.
+var imageSizes = []struct {
+	name          string
+	width, height int
+	crop          bool
+}{
+	{"thumbnail", 150, 150, true},
+	{"medium", 300, 300, false},
+	{"medium_large", 768, 0, false},
+	{"large", 1024, 1024, false},
+	{"1536x1536", 1536, 1536, false},
+	{"2048x2048", 2048, 2048, false},
+}
+
+func errInvalidMediaID() *apiError {
+	return newError(http.StatusNotFound, "rest_post_invalid_id", "Invalid post ID.")
+}
+
+func (s *Server) routeMedia(req *request, rest []string) (*response, *apiError) {
+	if len(rest) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return s.listMedia(req)
+		case http.MethodPost:
+			return s.uploadMedia(req)
+		}
+		return nil, errNoRoute()
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil || len(rest) > 2 || (len(rest) == 2 && (rest[1] != "edit" || req.method != http.MethodPost)) {
+		return nil, errNoRoute()
+	}
+
+	a, found := s.media[id]
+	if !found {
+		return nil, errInvalidMediaID()
+	}
+
+	if len(rest) == 2 {
+		return s.editMedia(req, a)
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return s.retrieveMedia(req, a)
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return s.updateMedia(req, a)
+	case http.MethodDelete:
+		return s.deleteMedia(req, a)
+	}
+
+	return nil, errNoRoute()
+}
+
+func (s *Server) listMedia(req *request) (*response, *apiError) {
+	context := req.context()
+	if context == "edit" && req.user == nil {
+		return nil, errForbiddenContext()
+	}
+
+	statuses := req.list("status")
+	if len(statuses) == 0 {
+		statuses = []string{"inherit"}
+	}
+	for _, status := range statuses {
+		if !slices.Contains([]string{"inherit", "private", "trash"}, status) {
+			return nil, errInvalidParam("status")
+		}
+		if status != "inherit" && req.user == nil {
+			return nil, newError(http.StatusUnauthorized, "rest_forbidden_status", "Status is forbidden.")
+		}
+	}
+
+	search := strings.ToLower(req.str("search"))
+	slugs := req.list("slug")
+	include := req.ints("include")
+	exclude := req.ints("exclude")
+	authors := req.ints("author")
+	authorsExclude := req.ints("author_exclude")
+	parents := req.ints("parent")
+	parentsExclude := req.ints("parent_exclude")
+	mediaType := req.str("media_type")
+	mimeType := req.str("mime_type")
+
+	items := []*attachment{}
+	for _, a := range s.media {
+		if !slices.Contains(statuses, a.status) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(a.title+" "+a.caption+" "+a.description), search) {
+			continue
+		}
+		if len(slugs) > 0 && !slices.Contains(slugs, a.slug) {
+			continue
+		}
+		if len(include) > 0 && !slices.Contains(include, a.id) {
+			continue
+		}
+		if slices.Contains(exclude, a.id) {
+			continue
+		}
+		if len(authors) > 0 && !slices.Contains(authors, a.author) {
+			continue
+		}
+		if slices.Contains(authorsExclude, a.author) {
+			continue
+		}
+		if len(parents) > 0 && !slices.Contains(parents, a.post) {
+			continue
+		}
+		if slices.Contains(parentsExclude, a.post) {
+			continue
+		}
+		if mediaType != "" && !strings.HasPrefix(a.mimeType, mediaType+"/") {
+			continue
+		}
+		if mimeType != "" && a.mimeType != mimeType {
+			continue
+		}
+		items = append(items, a)
+	}
+
+	if err := order(req, items, "date", map[string]func(a, b *attachment) int{
+		"date":    func(a, b *attachment) int { return cmp.Or(compareTimes(a.date, b.date), cmp.Compare(a.id, b.id)) },
+		"id":      func(a, b *attachment) int { return cmp.Compare(a.id, b.id) },
+		"title":   func(a, b *attachment) int { return strings.Compare(a.title, b.title) },
+		"slug":    func(a, b *attachment) int { return strings.Compare(a.slug, b.slug) },
+		"include": func(a, b *attachment) int { return cmp.Compare(indexOf(include, a.id), indexOf(include, b.id)) },
+	}); err != nil {
+		return nil, err
+	}
+
+	page, headers, apiErr := paginate(req, items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	body := []map[string]any{}
+	for _, a := range page {
+		body = append(body, s.renderMedia(a, context))
+	}
+
+	return &response{status: http.StatusOK, headers: headers, body: body}, nil
+}
+
+func (s *Server) retrieveMedia(req *request, a *attachment) (*response, *apiError) {
+	context := req.context()
+	if context == "edit" && req.user == nil {
+		return nil, errForbiddenContext()
+	}
+
+	if a.status != "inherit" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_forbidden", "Sorry, you are not allowed to do that.")
+	}
+
+	return ok(s.renderMedia(a, context)), nil
+}
+
+// uploadMedia creates an attachment from a raw request body named by its
+// Content-Disposition header, the way the media controller handles uploads
+// that are not multipart forms.
+func (s *Server) uploadMedia(req *request) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_create", "Sorry, you are not allowed to upload media on this site.")
+	}
+
+	if len(req.data) == 0 {
+		return nil, newError(http.StatusBadRequest, "rest_upload_no_data", "No data supplied.")
+	}
+	if req.Header.Get("Content-Type") == "" {
+		return nil, newError(http.StatusBadRequest, "rest_upload_no_content_type", "No Content-Type supplied.")
+	}
+
+	disposition := req.Header.Get("Content-Disposition")
+	if disposition == "" {
+		return nil, newError(http.StatusBadRequest, "rest_upload_no_content_disposition", "No Content-Disposition supplied.")
+	}
+	_, params, err := mime.ParseMediaType(disposition)
+	filename := path.Base(strings.ReplaceAll(params["filename"], "\\", "/"))
+	if err != nil || params["filename"] == "" {
+		return nil, newError(http.StatusBadRequest, "rest_upload_invalid_disposition", "Invalid Content-Disposition supplied. Content-Disposition needs to be formatted as `attachment; filename=\"image.png\"` or similar.")
+	}
+
+	a, apiErr := s.newAttachment(req, filename, req.data)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if err := s.applyMedia(req, a); err != nil {
+		return nil, err
+	}
+
+	s.media[a.id] = a
+	return created(s.renderMedia(a, "edit")), nil
+}
+
+// newAttachment stores data under a unique file name in this month's
+// uploads folder, reading the dimensions of images.
+func (s *Server) newAttachment(req *request, filename string, data []byte) (*attachment, *apiError) {
+	ext := strings.ToLower(path.Ext(filename))
+	mimeType, allowed := uploadTypes[ext]
+	if !allowed {
+		return nil, newError(http.StatusInternalServerError, "rest_upload_sideload_error", "Sorry, you are not allowed to upload this file type.")
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	a := &attachment{
+		author:   req.user.id,
+		status:   "inherit",
+		date:     now,
+		modified: now,
+		mimeType: mimeType,
+		data:     data,
+		meta:     map[string]any{},
+	}
+
+	if strings.HasPrefix(mimeType, "image/") && mimeType != "image/webp" {
+		config, format, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil || "image/"+format != mimeType {
+			return nil, newError(http.StatusInternalServerError, "rest_upload_sideload_error", "Sorry, you are not allowed to upload this file type.")
+		}
+		a.width, a.height = config.Width, config.Height
+	}
+
+	name := strings.TrimSuffix(filename, path.Ext(filename))
+	base := strings.Join(strings.Fields(name), "-")
+	a.file = now.Format("2006/01/") + base + ext
+	for i := 1; s.fileExists(a.file); i++ {
+		a.file = now.Format("2006/01/") + base + "-" + strconv.Itoa(i) + ext
+	}
+
+	a.title = name
+	a.slug = s.uniqueMediaSlug(sanitizeTitle(name), 0)
+	a.id = s.nextID()
+
+	return a, nil
+}
+
+func (s *Server) fileExists(file string) bool {
+	for _, other := range s.media {
+		if other.file == file {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) uniqueMediaSlug(slug string, id int) string {
+	taken := func(candidate string) bool {
+		for _, other := range s.media {
+			if other.id != id && other.slug == candidate {
+				return true
+			}
+		}
+		for _, p := range s.posts {
+			if p.slug == candidate {
+				return true
+			}
+		}
+		return false
+	}
+
+	unique := slug
+	for i := 2; taken(unique); i++ {
+		unique = slug + "-" + strconv.Itoa(i)
+	}
+	return unique
+}
+
+func (s *Server) updateMedia(req *request, a *attachment) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_edit", "Sorry, you are not allowed to edit this post.")
+	}
+
+	updated := *a
+	if err := s.applyMedia(req, &updated); err != nil {
+		return nil, err
+	}
+	updated.modified = time.Now().UTC().Truncate(time.Second)
+
+	*a = updated
+	return ok(s.renderMedia(a, "edit")), nil
+}
+
+func (s *Server) deleteMedia(req *request, a *attachment) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_delete", "Sorry, you are not allowed to delete this post.")
+	}
+
+	if !req.bool("force") {
+		return nil, newError(http.StatusNotImplemented, "rest_trash_not_supported", "The attachment does not support trashing. Set 'force=true' to delete.")
+	}
+
+	previous := s.renderMedia(a, "edit")
+	delete(s.media, a.id)
+	for _, p := range s.posts {
+		if p.featuredMedia == a.id {
+			p.featuredMedia = 0
+		}
+	}
+
+	return ok(map[string]any{"deleted": true, "previous": previous}), nil
+}
+
+// applyMedia copies the writable fields present in the request onto a.
+func (s *Server) applyMedia(req *request, a *attachment) *apiError {
+	if req.has("title") {
+		a.title = rawValue(req, "title")
+	}
+	if req.has("caption") {
+		a.caption = rawValue(req, "caption")
+	}
+	if req.has("description") {
+		a.description = rawValue(req, "description")
+	}
+	if req.has("alt_text") {
+		a.altText = req.str("alt_text")
+	}
+	if req.has("slug") {
+		a.slug = s.uniqueMediaSlug(sanitizeTitle(req.str("slug")), a.id)
+	}
+	if req.has("status") {
+		status := req.str("status")
+		switch status {
+		case "publish", "inherit":
+			status = "inherit"
+		case "private", "trash":
+		default:
+			return errInvalidParam("status")
+		}
+		a.status = status
+	}
+	if req.has("author") {
+		author := req.int("author")
+		if !s.userExists(author) {
+			return newError(http.StatusBadRequest, "rest_invalid_author", "Invalid author ID.")
+		}
+		a.author = author
+	}
+	if req.has("post") {
+		parent := req.int("post")
+		if p := s.posts[parent]; parent != 0 && (p == nil || p.postType == "revision") {
+			return newError(http.StatusBadRequest, "rest_invalid_param", "Invalid parent type.")
+		}
+		a.post = parent
+	}
+	if req.has("meta") {
+		meta, valid := req.body["meta"].(map[string]any)
+		if !valid {
+			return errInvalidParam("meta")
+		}
+		for key, value := range meta {
+			if value == nil {
+				delete(a.meta, key)
+				continue
+			}
+			a.meta[key] = value
+		}
+	}
+	return nil
+}
+
+// uploadURL returns the public URL of a file in the uploads folder.
+func (s *Server) uploadURL(file string) string {
+	return s.URL + "/wp-content/uploads/" + file
+}
+
+// serveUpload answers requests for uploaded originals, so URLs reported as
+// source_url can be downloaded.
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request) {
+	file := strings.TrimPrefix(r.URL.Path, "/wp-content/uploads/")
+
+	s.mu.Lock()
+	var found *attachment
+	for _, a := range s.media {
+		if a.file == file {
+			found = a
+		}
+	}
+	s.mu.Unlock()
+
+	if found == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", found.mimeType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(found.data)))
+	w.Write(found.data)
+}
+
+// resizeDimensions returns the size of a generated image the way
+// image_resize_dimensions computes it, or false when the original is not
+// larger than the requested size.
+func resizeDimensions(width, height, maxWidth, maxHeight int, crop bool) (int, int, bool) {
+	if crop {
+		if width <= maxWidth && height <= maxHeight {
+			return 0, 0, false
+		}
+		return min(width, maxWidth), min(height, maxHeight), true
+	}
+
+	scale := 1.0
+	if maxWidth > 0 {
+		scale = min(scale, float64(maxWidth)/float64(width))
+	}
+	if maxHeight > 0 {
+		scale = min(scale, float64(maxHeight)/float64(height))
+	}
+	if scale >= 1 {
+		return 0, 0, false
+	}
+	return max(1, int(float64(width)*scale+0.5)), max(1, int(float64(height)*scale+0.5)), true
+}
+
+func (s *Server) mediaDetails(a *attachment) map[string]any {
+	details := map[string]any{"filesize": len(a.data)}
+	if !strings.HasPrefix(a.mimeType, "image/") {
+		return details
+	}
+
+	dir, name := path.Split(a.file)
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	sizes := map[string]any{}
+	for _, size := range imageSizes {
+		width, height, resized := resizeDimensions(a.width, a.height, size.width, size.height, size.crop)
+		if !resized {
+			continue
+		}
+		file := base + "-" + strconv.Itoa(width) + "x" + strconv.Itoa(height) + ext
+		sizes[size.name] = map[string]any{
+			"file":       file,
+			"width":      width,
+			"height":     height,
+			"mime_type":  a.mimeType,
+			"source_url": s.uploadURL(dir + file),
+		}
+	}
+	sizes["full"] = map[string]any{
+		"file":       name,
+		"width":      a.width,
+		"height":     a.height,
+		"mime_type":  a.mimeType,
+		"source_url": s.uploadURL(a.file),
+	}
+
+	details["width"] = a.width
+	details["height"] = a.height
+	details["file"] = a.file
+	details["sizes"] = sizes
+	details["image_meta"] = map[string]any{
+		"aperture":          "0",
+		"credit":            "",
+		"camera":            "",
+		"caption":           "",
+		"created_timestamp": "0",
+		"copyright":         "",
+		"focal_length":      "0",
+		"iso":               "0",
+		"shutter_speed":     "0",
+		"title":             "",
+		"orientation":       "0",
+		"keywords":          []string{},
+	}
+	return details
+}
+
+func (s *Server) renderMedia(a *attachment, context string) map[string]any {
+	title := map[string]any{"rendered": a.title}
+	caption := map[string]any{"rendered": autop(a.caption)}
+	description := map[string]any{"rendered": autop(a.description)}
+
+	mediaType := "file"
+	if strings.HasPrefix(a.mimeType, "image/") {
+		mediaType = "image"
+	}
+
+	var parent any
+	if a.post != 0 {
+		parent = a.post
+	}
+
+	meta := map[string]any{}
+	for key, value := range a.meta {
+		meta[key] = value
+	}
+
+	data := map[string]any{
+		"id":             a.id,
+		"date":           s.localDate(a.date),
+		"date_gmt":       formatDate(a.date),
+		"guid":           map[string]any{"rendered": s.uploadURL(a.file)},
+		"link":           s.URL + "/?attachment_id=" + strconv.Itoa(a.id),
+		"modified":       s.localDate(a.modified),
+		"modified_gmt":   formatDate(a.modified),
+		"slug":           a.slug,
+		"status":         a.status,
+		"type":           "attachment",
+		"title":          title,
+		"author":         a.author,
+		"comment_status": "open",
+		"ping_status":    "closed",
+		"template":       "",
+		"meta":           meta,
+		"alt_text":       a.altText,
+		"caption":        caption,
+		"description":    description,
+		"media_type":     mediaType,
+		"mime_type":      a.mimeType,
+		"media_details":  s.mediaDetails(a),
+		"post":           parent,
+		"source_url":     s.uploadURL(a.file),
+	}
+
+	if context == "edit" {
+		title["raw"] = a.title
+		caption["raw"] = a.caption
+		description["raw"] = a.description
+		data["permalink_template"] = s.URL + "/?attachment_id=" + strconv.Itoa(a.id)
+		data["generated_slug"] = sanitizeTitle(a.title)
+		data["missing_image_sizes"] = []string{}
+	}
+
+	return s.addFields("attachment", a.id, data)
+}
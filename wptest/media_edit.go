@@ -0,0 +1,157 @@
+package wptest
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// editMedia applies the modifiers of a /media/{id}/edit request to the
+// original image and saves the result as a new attachment.
+func (s *Server) editMedia(req *request, a *attachment) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_edit_image", "Sorry, you are not allowed to upload media on this site.")
+	}
+
+	if a.width == 0 {
+		return nil, newError(http.StatusBadRequest, "rest_cannot_edit_file_type", "This type of file cannot be edited.")
+	}
+
+	if !req.has("src") {
+		return nil, errMissingParam("src")
+	}
+	if req.str("src") != s.uploadURL(a.file) {
+		return nil, newError(http.StatusNotFound, "rest_unknown_attachment", "Unable to get meta information for file.")
+	}
+
+	modifiers, _ := req.body["modifiers"].([]any)
+	if len(modifiers) == 0 {
+		return nil, newError(http.StatusBadRequest, "rest_image_not_edited", "The image was not edited. Edit the image before applying the changes.")
+	}
+
+	decoded, format, err := image.Decode(bytes.NewReader(a.data))
+	if err != nil {
+		return nil, newError(http.StatusInternalServerError, "rest_unknown_image_file_type", "Unable to edit this image.")
+	}
+	img := toNRGBA(decoded)
+
+	for _, item := range modifiers {
+		modifier, _ := item.(map[string]any)
+		args, _ := modifier["args"].(map[string]any)
+		inner := &request{body: args}
+
+		switch modifier["type"] {
+		case "rotate":
+			angle := inner.number("angle")
+			if math.Mod(angle, 90) != 0 {
+				return nil, newError(http.StatusInternalServerError, "rest_image_rotation_failed", "Unable to rotate this image.")
+			}
+			for range (int(angle)%360 + 360) % 360 / 90 {
+				img = rotateClockwise(img)
+			}
+		case "crop":
+			bounds := img.Bounds()
+			left := int(math.Round(inner.number("left") * float64(bounds.Dx()) / 100))
+			top := int(math.Round(inner.number("top") * float64(bounds.Dy()) / 100))
+			width := int(math.Round(inner.number("width") * float64(bounds.Dx()) / 100))
+			height := int(math.Round(inner.number("height") * float64(bounds.Dy()) / 100))
+			rect := image.Rect(left, top, left+width, top+height).Intersect(bounds)
+			if width <= 0 || height <= 0 || rect.Empty() {
+				return nil, newError(http.StatusInternalServerError, "rest_image_crop_failed", "Unable to crop this image.")
+			}
+			img = toNRGBA(img.SubImage(rect))
+		case "flip":
+			flip, _ := args["flip"].(map[string]any)
+			axes := &request{body: flip}
+			img = flipImage(img, axes.bool("horizontal"), axes.bool("vertical"))
+		default:
+			return nil, errInvalidParam("modifiers")
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 82})
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	default:
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, newError(http.StatusInternalServerError, "rest_image_not_edited", "Unable to save the edited image.")
+	}
+
+	name := path.Base(a.file)
+	ext := path.Ext(name)
+	edited, apiErr := s.newAttachment(req, strings.TrimSuffix(name, ext)+"-edited"+ext, buf.Bytes())
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	edited.title = a.title
+	edited.caption = a.caption
+	edited.description = a.description
+	edited.altText = a.altText
+	edited.slug = s.uniqueMediaSlug(sanitizeTitle(a.title), edited.id)
+
+	s.media[edited.id] = edited
+	return created(s.renderMedia(edited, "edit")), nil
+}
+
+// number reads a numeric argument given as a JSON number or a string.
+func (r *request) number(key string) float64 {
+	v, _ := r.value(key)
+	switch v := v.(type) {
+	case float64:
+		return v
+	case string:
+		n, _ := strconv.ParseFloat(v, 64)
+		return n
+	}
+	return 0
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+func rotateClockwise(src *image.NRGBA) *image.NRGBA {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, height, width))
+	for y := range height {
+		for x := range width {
+			dst.Set(height-1-y, x, src.At(x, y))
+		}
+	}
+	return dst
+}
+
+func flipImage(src *image.NRGBA, horizontal, vertical bool) *image.NRGBA {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewNRGBA(src.Bounds())
+	for y := range height {
+		for x := range width {
+			tx, ty := x, y
+			if horizontal {
+				tx = width - 1 - x
+			}
+			if vertical {
+				ty = height - 1 - y
+			}
+			dst.Set(tx, ty, src.At(x, y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,217 @@
+package wptest
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type plugin struct {
+	file        string
+	name        string
+	description string
+	author      string
+	version     string
+	requiresWP  string
+	requiresPHP string
+	status      string
+}
+
+// pluginDirectory is the part of the WordPress.org plugin directory the
+// server can install from, keyed by slug.
+var pluginDirectory = map[string]plugin{
+	"akismet": {
+		file:        "akismet/akismet",
+		name:        "Akismet Anti-spam: Spam Protection",
+		description: "The best anti-spam protection to block spam comments and spam in a contact form.",
+		author:      "Automattic - Anti-spam Team",
+		version:     "5.3.7",
+		requiresWP:  "5.8",
+		requiresPHP: "5.6.20",
+	},
+	"classic-editor": {
+		file:        "classic-editor/classic-editor",
+		name:        "Classic Editor",
+		description: "Enables the WordPress classic editor and the old-style Edit Post screen with TinyMCE, Meta Boxes, etc.",
+		author:      "WordPress Contributors",
+		version:     "1.6.7",
+		requiresWP:  "4.9",
+		requiresPHP: "5.2.4",
+	},
+	"query-monitor": {
+		file:        "query-monitor/query-monitor",
+		name:        "Query Monitor",
+		description: "The developer tools panel for WordPress.",
+		author:      "John Blackbourn",
+		version:     "3.17.2",
+		requiresWP:  "6.1",
+		requiresPHP: "7.4",
+	},
+}
+
+// seedPlugins installs the plugins bundled with a fresh install, all
+// inactive.
+func (s *Server) seedPlugins() {
+	akismet := pluginDirectory["akismet"]
+	akismet.status = "inactive"
+	s.plugins[akismet.file] = &akismet
+
+	s.plugins["hello"] = &plugin{
+		file:        "hello",
+		name:        "Hello Dolly",
+		description: "This is not just a plugin, it symbolizes the hope and enthusiasm of an entire generation summed up in two words sung most famously by Louis Armstrong.",
+		author:      "Matt Mullenweg",
+		version:     "1.7.2",
+		status:      "inactive",
+	}
+}
+
+func (s *Server) routePlugins(req *request, rest []string) (*response, *apiError) {
+	if len(rest) > 2 {
+		return nil, errNoRoute()
+	}
+
+	if req.user == nil {
+		if req.method == http.MethodGet {
+			return nil, newError(http.StatusUnauthorized, "rest_cannot_view_plugins", "Sorry, you are not allowed to manage plugins for this site.")
+		}
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_manage_plugins", "Sorry, you are not allowed to manage plugins for this site.")
+	}
+
+	if len(rest) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return s.listPlugins(req)
+		case http.MethodPost:
+			return s.installPlugin(req)
+		}
+		return nil, errNoRoute()
+	}
+
+	p, found := s.plugins[strings.Join(rest, "/")]
+	if !found {
+		return nil, newError(http.StatusNotFound, "rest_plugin_not_found", "Plugin not found.")
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(renderPlugin(p)), nil
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return s.updatePlugin(req, p)
+	case http.MethodDelete:
+		return s.deletePlugin(p)
+	}
+
+	return nil, errNoRoute()
+}
+
+func (s *Server) listPlugins(req *request) (*response, *apiError) {
+	statuses := req.list("status")
+	for _, status := range statuses {
+		if !s.validPluginStatus(status) {
+			return nil, errInvalidParam("status")
+		}
+	}
+	search := strings.ToLower(req.str("search"))
+
+	files := []string{}
+	for file, p := range s.plugins {
+		if len(statuses) > 0 && !slices.Contains(statuses, p.status) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(p.name+" "+p.description+" "+p.author), search) {
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	body := []map[string]any{}
+	for _, file := range files {
+		body = append(body, renderPlugin(s.plugins[file]))
+	}
+
+	return ok(body), nil
+}
+
+func (s *Server) installPlugin(req *request) (*response, *apiError) {
+	if !req.has("slug") {
+		return nil, errMissingParam("slug")
+	}
+	status := "inactive"
+	if req.has("status") {
+		status = req.str("status")
+		if !s.validPluginStatus(status) {
+			return nil, errInvalidParam("status")
+		}
+	}
+	p, found := pluginDirectory[req.str("slug")]
+	if !found {
+		return nil, newError(http.StatusNotFound, "plugins_api_failed", "Plugin not found.")
+	}
+	if s.FilesystemCredentialsRequired {
+		return nil, newError(http.StatusInternalServerError, "unable_to_connect_to_filesystem", "Unable to connect to the filesystem. Please confirm your credentials.")
+	}
+	if _, exists := s.plugins[p.file]; exists {
+		return nil, newError(http.StatusInternalServerError, "folder_exists", "Destination folder already exists.")
+	}
+
+	p.status = status
+	s.plugins[p.file] = &p
+
+	return created(renderPlugin(&p)), nil
+}
+
+func (s *Server) updatePlugin(req *request, p *plugin) (*response, *apiError) {
+	if req.has("status") {
+		status := req.str("status")
+		if !s.validPluginStatus(status) {
+			return nil, errInvalidParam("status")
+		}
+		p.status = status
+	}
+
+	return ok(renderPlugin(p)), nil
+}
+
+func (s *Server) deletePlugin(p *plugin) (*response, *apiError) {
+	if p.status != "inactive" {
+		return nil, newError(http.StatusBadRequest, "rest_cannot_delete_active_plugin", "Cannot delete an active plugin. Please deactivate it first.")
+	}
+	if s.FilesystemCredentialsRequired {
+		return nil, errFilesystemUnavailable()
+	}
+
+	previous := renderPlugin(p)
+	delete(s.plugins, p.file)
+
+	return ok(map[string]any{"deleted": true, "previous": previous}), nil
+}
+
+// validPluginStatus reports whether status is in the schema's enum, which
+// only has network-active on multisite.
+func (s *Server) validPluginStatus(status string) bool {
+	return status == "active" || status == "inactive" || (status == "network-active" && s.Multisite)
+}
+
+func errFilesystemUnavailable() *apiError {
+	return newError(http.StatusInternalServerError, "fs_unavailable", "The filesystem is currently unavailable for managing plugins.")
+}
+
+func renderPlugin(p *plugin) map[string]any {
+	return map[string]any{
+		"plugin":       p.file,
+		"status":       p.status,
+		"name":         p.name,
+		"plugin_uri":   "https://wordpress.org/plugins/" + strings.Split(p.file, "/")[0] + "/",
+		"author":       p.author,
+		"author_uri":   "",
+		"description":  map[string]string{"raw": p.description, "rendered": p.description},
+		"version":      p.version,
+		"network_only": false,
+		"requires_wp":  p.requiresWP,
+		"requires_php": p.requiresPHP,
+		"textdomain":   strings.Split(p.file, "/")[0],
+	}
+}
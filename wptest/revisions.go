@@ -0,0 +1,205 @@
+package wptest
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// saveRevision stores a copy of the post as a revision, or replaces the
+// author's autosave when autosave is set.
+func (s *Server) saveRevision(p *post, author int, autosave bool) *post {
+	now := time.Now().UTC().Truncate(time.Second)
+	slug := fmt.Sprintf("%d-revision-v1", p.id)
+
+	if autosave {
+		slug = fmt.Sprintf("%d-autosave-v1", p.id)
+		for _, existing := range s.posts {
+			if existing.postType == "revision" && existing.parent == p.id &&
+				existing.slug == slug && existing.author == author {
+				delete(s.posts, existing.id)
+			}
+		}
+	}
+
+	revision := &post{
+		id:       s.nextID(),
+		postType: "revision",
+		status:   "inherit",
+		slug:     slug,
+		title:    p.title,
+		content:  p.content,
+		excerpt:  p.excerpt,
+		author:   author,
+		parent:   p.id,
+		date:     now,
+		dateSet:  true,
+		modified: now,
+	}
+	s.posts[revision.id] = revision
+
+	return revision
+}
+
+func (s *Server) revisionsOf(parent int, autosavesOnly bool) []*post {
+	revisions := []*post{}
+	for _, p := range s.posts {
+		if p.postType != "revision" || p.parent != parent {
+			continue
+		}
+		if autosavesOnly && !strings.HasSuffix(p.slug, "-autosave-v1") {
+			continue
+		}
+		revisions = append(revisions, p)
+	}
+	return revisions
+}
+
+func (s *Server) revisionParent(req *request, postType string, parentID int) (*post, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_read", "Sorry, you are not allowed to view revisions of this post.")
+	}
+
+	parent := s.findPost(postType, parentID)
+	if parent == nil {
+		return nil, newError(http.StatusNotFound, "rest_post_invalid_parent", "Invalid post parent ID.")
+	}
+
+	return parent, nil
+}
+
+func (s *Server) routeRevisions(req *request, postType string, parentID int, rest []string) (*response, *apiError) {
+	parent, apiErr := s.revisionParent(req, postType, parentID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if len(rest) == 0 {
+		if req.method != http.MethodGet {
+			return nil, errNoRoute()
+		}
+		return s.listRevisions(req, s.revisionsOf(parent.id, false))
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil || len(rest) > 1 {
+		return nil, errNoRoute()
+	}
+
+	revision, found := s.posts[id]
+	if !found || revision.postType != "revision" {
+		return nil, newError(http.StatusNotFound, "rest_post_invalid_id", "Invalid revision ID.")
+	}
+	if revision.parent != parent.id {
+		return nil, newError(http.StatusNotFound, "rest_revision_parent_id_mismatch", "The revision does not belong to the specified parent.")
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(s.renderRevision(revision, req.context())), nil
+	case http.MethodDelete:
+		if !req.bool("force") {
+			return nil, newError(http.StatusNotImplemented, "rest_trash_not_supported", "Revisions do not support trashing. Set 'force' to true to delete.")
+		}
+		previous := s.renderRevision(revision, "edit")
+		delete(s.posts, revision.id)
+		return ok(map[string]any{"deleted": true, "previous": previous}), nil
+	}
+
+	return nil, errNoRoute()
+}
+
+func (s *Server) routeAutosaves(req *request, postType string, parentID int, rest []string) (*response, *apiError) {
+	parent, apiErr := s.revisionParent(req, postType, parentID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if len(rest) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return s.listRevisions(req, s.revisionsOf(parent.id, true))
+		case http.MethodPost:
+			draft := *parent
+			if err := s.applyPost(req, &draft); err != nil {
+				return nil, err
+			}
+			return ok(s.renderRevision(s.saveRevision(&draft, req.user.id, true), "edit")), nil
+		}
+		return nil, errNoRoute()
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil || len(rest) > 1 || req.method != http.MethodGet {
+		return nil, errNoRoute()
+	}
+
+	for _, autosave := range s.revisionsOf(parent.id, true) {
+		if autosave.id == id {
+			return ok(s.renderRevision(autosave, req.context())), nil
+		}
+	}
+
+	return nil, newError(http.StatusNotFound, "rest_post_invalid_id", "Invalid revision ID.")
+}
+
+func (s *Server) listRevisions(req *request, revisions []*post) (*response, *apiError) {
+	if err := order(req, revisions, "date", map[string]func(a, b *post) int{
+		"date":    func(a, b *post) int { return cmp.Or(compareTimes(a.date, b.date), cmp.Compare(a.id, b.id)) },
+		"id":      func(a, b *post) int { return cmp.Compare(a.id, b.id) },
+		"title":   func(a, b *post) int { return strings.Compare(a.title, b.title) },
+		"slug":    func(a, b *post) int { return strings.Compare(a.slug, b.slug) },
+		"include": func(a, b *post) int { return cmp.Compare(a.id, b.id) },
+	}); err != nil {
+		return nil, err
+	}
+
+	// Revisions are only paginated when asked to, unlike other collections.
+	headers := http.Header{}
+	if req.has("per_page") || req.has("page") {
+		var apiErr *apiError
+		revisions, headers, apiErr = paginate(req, revisions)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+	}
+
+	body := []map[string]any{}
+	for _, revision := range revisions {
+		body = append(body, s.renderRevision(revision, req.context()))
+	}
+
+	return &response{status: http.StatusOK, headers: headers, body: body}, nil
+}
+
+func (s *Server) renderRevision(r *post, context string) map[string]any {
+	title := map[string]any{"rendered": r.title}
+	content := map[string]any{"rendered": autop(r.content)}
+	excerpt := map[string]any{"rendered": autop(r.excerpt)}
+
+	data := map[string]any{
+		"author":       r.author,
+		"date":         s.localDate(r.date),
+		"date_gmt":     formatDate(r.date),
+		"id":           r.id,
+		"modified":     s.localDate(r.modified),
+		"modified_gmt": formatDate(r.modified),
+		"parent":       r.parent,
+		"slug":         r.slug,
+		"guid":         map[string]any{"rendered": s.URL + "/?p=" + strconv.Itoa(r.id)},
+		"title":        title,
+		"content":      content,
+		"excerpt":      excerpt,
+	}
+
+	if context == "edit" {
+		title["raw"] = r.title
+		content["raw"] = r.content
+		excerpt["raw"] = r.excerpt
+	}
+
+	return data
+}
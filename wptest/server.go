@@ -0,0 +1,719 @@
+// Package wptest provides an in-memory fake of the WordPress REST API backed
+// by net/http/httptest, so code built on gowprest can be tested without a
+// live site.
+//
+// The fake implements the discovery index, posts, pages, their revisions and
+// autosaves, media, comments, categories, tags, users, taxonomies, post
+// types, settings, themes and plugins, and serves uploaded files. It
+// mirrors the behaviour of a fresh WordPress install closely enough for
+// client code: Basic auth with application passwords, X-WP-Total and
+// X-WP-TotalPages pagination headers, trash semantics and WordPress-shaped
+// error bodies.
+package wptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials of the administrator every Server is seeded with.
+const (
+	Username = "admin"
+	Password = "abcd EFGH 1234 ijkl MNOP 6789"
+)
+
+const dateLayout = "2006-01-02T15:04:05"
+
+// Server is a fake WordPress site. The zero value is not usable; create one
+// with NewServer and release it with Close.
+type Server struct {
+	*httptest.Server
+
+	// Name and Description are reported by the discovery index.
+	Name        string
+	Description string
+	// Location is the site's time zone, used for the local date fields and
+	// to read dates sent without an offset. It defaults to UTC.
+	Location *time.Location
+	// AllowThemeActivation adds the route to activate a theme, which core
+	// WordPress lacks but some hosts provide.
+	AllowThemeActivation bool
+	// FilesystemCredentialsRequired makes installing and deleting plugins
+	// fail as on a site that needs FTP credentials to write files.
+	FilesystemCredentialsRequired bool
+	// Multisite lets plugins be network-active.
+	Multisite bool
+
+	mu       sync.Mutex
+	lastID   int
+	lastUser int
+	users    map[string]*user
+	posts    map[int]*post
+	comments map[int]*comment
+	media    map[int]*attachment
+	terms    map[int]*term
+	fields   map[string]map[string]func(id int) any
+	settings map[string]any
+	options  map[string]any
+	plugins  map[string]*plugin
+	// stylesheet is the active theme.
+	stylesheet string
+}
+
+// NewServer starts a fake site seeded like a fresh WordPress install: the
+// admin user, the "Uncategorized" category, the "Hello world!" post with one
+// comment, the "Sample Page" and the inactive Akismet and Hello Dolly
+// plugins.
+func NewServer() *Server {
+	s := &Server{
+		Name:        "Test Blog",
+		Description: "Just another WordPress site",
+		Location:    time.UTC,
+		users:       make(map[string]*user),
+		posts:       make(map[int]*post),
+		comments:    make(map[int]*comment),
+		media:       make(map[int]*attachment),
+		terms:       make(map[int]*term),
+		fields:      make(map[string]map[string]func(id int) any),
+		settings:    make(map[string]any),
+		options:     make(map[string]any),
+		plugins:     make(map[string]*plugin),
+		stylesheet:  "twentytwentyfive",
+	}
+
+	s.seed()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// AddUser registers an administrator with an application password and
+// returns its ID.
+func (s *Server) AddUser(username, password string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addUser(username, password)
+}
+
+// RegisterField adds the field name to every object of objectType the server
+// renders, like register_rest_field: objectType is a post type such as
+// "post" or "page", a taxonomy such as "category", "comment" or "user", or
+// "taxonomy" and "type" for the objects of /wp/v2/taxonomies and
+// /wp/v2/types. The value is computed by get from the object's ID, which is
+// 0 for taxonomies and types; get must not call the server.
+func (s *Server) RegisterField(objectType, name string, get func(id int) any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fields[objectType] == nil {
+		s.fields[objectType] = make(map[string]func(id int) any)
+	}
+	s.fields[objectType][name] = get
+}
+
+// addFields sets the registered fields of objectType on data.
+func (s *Server) addFields(objectType string, id int, data map[string]any) map[string]any {
+	for name, get := range s.fields[objectType] {
+		data[name] = get(id)
+	}
+	return data
+}
+
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *Server) seed() {
+	now := time.Now().UTC().Truncate(time.Second)
+	author := s.addUser(Username, Password)
+
+	uncategorized := &term{
+		id:       s.nextID(),
+		taxonomy: "category",
+		name:     "Uncategorized",
+		slug:     "uncategorized",
+	}
+	s.terms[uncategorized.id] = uncategorized
+
+	hello := &post{
+		id:            s.nextID(),
+		postType:      "post",
+		status:        "publish",
+		slug:          "hello-world",
+		title:         "Hello world!",
+		content:       "Welcome to WordPress. This is your first post. Edit or delete it, then start writing!",
+		author:        author,
+		date:          now,
+		dateSet:       true,
+		modified:      now,
+		commentStatus: "open",
+		pingStatus:    "open",
+		format:        "standard",
+		categories:    []int{uncategorized.id},
+		tags:          []int{},
+	}
+	s.posts[hello.id] = hello
+
+	sample := &post{
+		id:            s.nextID(),
+		postType:      "page",
+		status:        "publish",
+		slug:          "sample-page",
+		title:         "Sample Page",
+		content:       "This is an example page.",
+		author:        author,
+		date:          now,
+		dateSet:       true,
+		modified:      now,
+		commentStatus: "closed",
+		pingStatus:    "open",
+	}
+	s.posts[sample.id] = sample
+
+	first := &comment{
+		id:         s.nextID(),
+		post:       hello.id,
+		authorName: "A WordPress Commenter",
+		authorURL:  "https://wordpress.org/",
+		content:    "Hi, this is a comment.",
+		date:       now,
+		status:     "approved",
+	}
+	s.comments[first.id] = first
+
+	s.seedPlugins()
+}
+
+// request is an incoming API call with its query and JSON body merged the way
+// WordPress merges them into WP_REST_Request parameters.
+type request struct {
+	*http.Request
+	method string
+	route  []string
+	query  url.Values
+	body   map[string]any
+	data   []byte
+	user   *user
+
+	// appPassword is the application password the user authenticated with.
+	appPassword *appPassword
+}
+
+func (r *request) value(key string) (any, bool) {
+	if v, ok := r.body[key]; ok {
+		return v, true
+	}
+	if r.query.Has(key) {
+		return r.query.Get(key), true
+	}
+	if r.query.Has(key + "[]") {
+		values := []any{}
+		for _, v := range r.query[key+"[]"] {
+			values = append(values, v)
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+func (r *request) has(key string) bool {
+	_, ok := r.value(key)
+	return ok
+}
+
+func (r *request) str(key string) string {
+	v, ok := r.value(key)
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	if f, ok := v.(float64); ok && f == math.Trunc(f) {
+		return strconv.Itoa(int(f))
+	}
+	return fmt.Sprint(v)
+}
+
+func (r *request) int(key string) int {
+	n, _ := strconv.Atoi(r.str(key))
+	return n
+}
+
+func (r *request) bool(key string) bool {
+	v, ok := r.value(key)
+	if !ok {
+		return false
+	}
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || v == "1"
+	case float64:
+		return v != 0
+	}
+	return false
+}
+
+// list returns a list argument given as a JSON array, an array query
+// argument or a comma separated string.
+func (r *request) list(key string) []string {
+	v, ok := r.value(key)
+	if !ok || v == nil {
+		return nil
+	}
+
+	values := []string{}
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			values = append(values, strings.TrimSpace(fmt.Sprint(item)))
+		}
+	default:
+		for _, item := range strings.Split(fmt.Sprint(v), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
+
+func (r *request) ints(key string) []int {
+	ids := []int{}
+	for _, v := range r.list(key) {
+		if id, err := strconv.Atoi(v); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// taxQuery is a term filter of the posts controller, given either as a list
+// of term IDs or as an object with terms, include_children and operator.
+type taxQuery struct {
+	terms           []int
+	includeChildren bool
+	operator        string
+}
+
+func (r *request) taxQuery(key string) taxQuery {
+	if r.has(key) {
+		if object, ok := r.body[key].(map[string]any); ok {
+			inner := &request{body: object, query: url.Values{}}
+			return taxQuery{
+				terms:           inner.ints("terms"),
+				includeChildren: inner.bool("include_children"),
+				operator:        strings.ToUpper(inner.str("operator")),
+			}
+		}
+		return taxQuery{terms: r.ints(key)}
+	}
+	return taxQuery{
+		terms:           r.ints(key + "[terms]"),
+		includeChildren: r.bool(key + "[include_children]"),
+		operator:        strings.ToUpper(r.str(key + "[operator]")),
+	}
+}
+
+func (r *request) context() string {
+	if context := r.str("context"); context != "" {
+		return context
+	}
+	return "view"
+}
+
+// apiError is a WP_Error as serialized by the REST server.
+type apiError struct {
+	status  int
+	code    string
+	message string
+	data    map[string]any
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newError(status int, code, message string) *apiError {
+	return &apiError{status: status, code: code, message: message}
+}
+
+func errInvalidParam(params ...string) *apiError {
+	return newError(http.StatusBadRequest, "rest_invalid_param", "Invalid parameter(s): "+strings.Join(params, ", "))
+}
+
+func errMissingParam(params ...string) *apiError {
+	return newError(http.StatusBadRequest, "rest_missing_callback_param", "Missing parameter(s): "+strings.Join(params, ", "))
+}
+
+func errForbiddenContext() *apiError {
+	return newError(http.StatusUnauthorized, "rest_forbidden_context", "Sorry, you are not allowed to edit posts in this post type.")
+}
+
+// response is a successful API answer.
+type response struct {
+	status  int
+	headers http.Header
+	body    any
+}
+
+func ok(body any) *response {
+	return &response{status: http.StatusOK, body: body}
+}
+
+func created(body any) *response {
+	return &response{status: http.StatusCreated, body: body}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/wp-content/uploads/") {
+		s.serveUpload(w, r)
+		return
+	}
+
+	req, apiErr := s.parse(r)
+
+	var res *response
+	if apiErr == nil {
+		s.mu.Lock()
+		res, apiErr = s.route(req)
+		s.mu.Unlock()
+	}
+
+	if apiErr != nil {
+		data := map[string]any{"status": apiErr.status}
+		for key, value := range apiErr.data {
+			data[key] = value
+		}
+		res = &response{
+			status: apiErr.status,
+			body:   map[string]any{"code": apiErr.code, "message": apiErr.message, "data": data},
+		}
+	}
+
+	if res.headers == nil {
+		res.headers = http.Header{}
+	}
+
+	if r.URL.Query().Has("_envelope") {
+		headers := map[string]any{}
+		for key := range res.headers {
+			headers[key] = res.headers.Get(key)
+		}
+		res = &response{
+			status: http.StatusOK,
+			body:   map[string]any{"body": res.body, "status": res.status, "headers": headers},
+		}
+	} else {
+		for key, values := range res.headers {
+			w.Header()[key] = values
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(res.status)
+	json.NewEncoder(w).Encode(res.body)
+}
+
+// parse resolves the route, the method override, the parameters and the
+// authenticated user of an incoming call.
+func (s *Server) parse(r *http.Request) (*request, *apiError) {
+	path := r.URL.Path
+	if route := r.URL.Query().Get("rest_route"); route != "" {
+		path = route
+	} else {
+		path = strings.TrimPrefix(path, "/wp-json")
+	}
+
+	req := &request{
+		Request: r,
+		method:  r.Method,
+		query:   r.URL.Query(),
+		body:    map[string]any{},
+	}
+
+	if trimmed := strings.Trim(path, "/"); trimmed != "" {
+		req.route = strings.Split(trimmed, "/")
+	}
+
+	if method := r.URL.Query().Get("_method"); method != "" && r.Method == http.MethodPost {
+		req.method = strings.ToUpper(method)
+	} else if method := r.Header.Get("X-HTTP-Method-Override"); method != "" && r.Method == http.MethodPost {
+		req.method = strings.ToUpper(method)
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, newError(http.StatusBadRequest, "rest_invalid_json", err.Error())
+	}
+
+	req.data = data
+
+	contentType := r.Header.Get("Content-Type")
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if strings.Contains(contentType, "application/json") {
+			if err := json.Unmarshal(data, &req.body); err != nil {
+				return nil, newError(http.StatusBadRequest, "rest_invalid_json", "Invalid JSON body passed.")
+			}
+		} else if contentType == "" || strings.Contains(contentType, "application/x-www-form-urlencoded") {
+			if form, err := url.ParseQuery(string(data)); err == nil {
+				for key := range form {
+					req.body[key] = form.Get(key)
+				}
+			}
+		}
+	}
+
+	if username, password, ok := r.BasicAuth(); ok {
+		s.mu.Lock()
+		u, found := s.users[username]
+		s.mu.Unlock()
+
+		if !found {
+			return nil, newError(http.StatusUnauthorized, "invalid_username", "Unknown username. Check again or try your email address.")
+		}
+
+		s.mu.Lock()
+		used := u.appPassword(password)
+		if used != nil {
+			now := time.Now().UTC().Truncate(time.Second)
+			used.lastUsed = &now
+			used.lastIP, _, _ = strings.Cut(r.RemoteAddr, ":")
+		}
+		s.mu.Unlock()
+
+		if used == nil {
+			return nil, newError(http.StatusUnauthorized, "incorrect_password", "The provided password is an invalid application password.")
+		}
+		req.user = u
+		req.appPassword = used
+	}
+
+	return req, nil
+}
+
+func (s *Server) route(req *request) (*response, *apiError) {
+	route := req.route
+	if len(route) == 0 {
+		if req.method != http.MethodGet {
+			return nil, errNoRoute()
+		}
+		return ok(s.index()), nil
+	}
+
+	if len(route) < 3 || route[0] != "wp" || route[1] != "v2" {
+		return nil, errNoRoute()
+	}
+
+	switch route[2] {
+	case "posts", "pages":
+		return s.routePosts(req, route[2], route[3:])
+	case "comments":
+		return s.routeComments(req, route[3:])
+	case "media":
+		return s.routeMedia(req, route[3:])
+	case "users":
+		return s.routeUsers(req, route[3:])
+	case "categories":
+		return s.routeTerms(req, "category", route[3:])
+	case "tags":
+		return s.routeTerms(req, "post_tag", route[3:])
+	case "taxonomies":
+		return s.routeTaxonomies(req, route[3:])
+	case "types":
+		return s.routeTypes(req, route[3:])
+	case "settings":
+		return s.routeSettings(req, route[3:])
+	case "themes":
+		return s.routeThemes(req, route[3:])
+	case "plugins":
+		return s.routePlugins(req, route[3:])
+	}
+
+	return nil, errNoRoute()
+}
+
+func errNoRoute() *apiError {
+	return newError(http.StatusNotFound, "rest_no_route", "No route was found matching the URL and request method.")
+}
+
+func (s *Server) index() map[string]any {
+	_, offset := time.Now().In(s.Location).Zone()
+	settings := s.renderSettings()
+
+	return map[string]any{
+		"name":            s.Name,
+		"description":     s.Description,
+		"url":             s.URL,
+		"home":            s.URL,
+		"gmt_offset":      strconv.FormatFloat(float64(offset)/3600, 'f', -1, 64),
+		"timezone_string": settings["timezone"],
+		"page_for_posts":  settings["page_for_posts"],
+		"page_on_front":   settings["page_on_front"],
+		"show_on_front":   settings["show_on_front"],
+		"namespaces":      []string{"oembed/1.0", "wp/v2"},
+		"authentication": map[string]any{
+			"application-passwords": map[string]any{
+				"endpoints": map[string]any{
+					"authorization": s.URL + "/wp-admin/authorize-application.php",
+				},
+			},
+		},
+		"routes":        map[string]any{},
+		"site_logo":     settings["site_logo"],
+		"site_icon":     settings["site_icon"],
+		"site_icon_url": "",
+	}
+}
+
+// paginate applies page, per_page and offset to items and sets the
+// pagination headers WordPress sends with every collection.
+func paginate[T any](req *request, items []T) ([]T, http.Header, *apiError) {
+	perPage := 10
+	if req.has("per_page") {
+		perPage = req.int("per_page")
+		if perPage < 1 || perPage > 100 {
+			return nil, nil, errInvalidParam("per_page")
+		}
+	}
+
+	page := 1
+	if req.has("page") {
+		page = req.int("page")
+		if page < 1 {
+			return nil, nil, errInvalidParam("page")
+		}
+	}
+
+	offset := (page - 1) * perPage
+	if req.has("offset") {
+		offset = req.int("offset")
+	}
+
+	total := len(items)
+	totalPages := int(math.Ceil(float64(total) / float64(perPage)))
+	if page > totalPages && total > 0 {
+		return nil, nil, newError(http.StatusBadRequest, "rest_post_invalid_page_number", "The page number requested is larger than the number of pages available.")
+	}
+
+	start := min(offset, total)
+	end := min(start+perPage, total)
+
+	headers := http.Header{}
+	headers.Set("X-WP-Total", strconv.Itoa(total))
+	headers.Set("X-WP-TotalPages", strconv.Itoa(totalPages))
+
+	link := func(page int) string {
+		query := req.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		u := *req.URL
+		u.RawQuery = query.Encode()
+		return "http://" + req.Host + u.RequestURI()
+	}
+
+	links := []string{}
+	if page > 1 {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"prev\"", link(page-1)))
+	}
+	if page < totalPages {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"next\"", link(page+1)))
+	}
+	if len(links) > 0 {
+		headers.Set("Link", strings.Join(links, ", "))
+	}
+
+	return items[start:end], headers, nil
+}
+
+// order sorts items by the orderby argument, falling back to def, and the
+// order argument, falling back to desc when the key is a date.
+func order[T any](req *request, items []T, def string, keys map[string]func(a, b T) int) *apiError {
+	orderBy := req.str("orderby")
+	if orderBy == "" {
+		orderBy = def
+	}
+
+	compare, found := keys[orderBy]
+	if !found {
+		return errInvalidParam("orderby")
+	}
+
+	direction := req.str("order")
+	if direction == "" {
+		direction = "asc"
+		if orderBy == "date" || orderBy == "date_gmt" || orderBy == "modified" {
+			direction = "desc"
+		}
+	}
+
+	if direction != "asc" && direction != "desc" {
+		return errInvalidParam("order")
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if direction == "desc" {
+			return compare(items[i], items[j]) > 0
+		}
+		return compare(items[i], items[j]) < 0
+	})
+
+	return nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// sanitizeTitle approximates sanitize_title for ASCII input.
+func sanitizeTitle(title string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(title), "-"), "-")
+}
+
+// autop approximates wpautop for single paragraph content.
+func autop(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "<p>" + text + "</p>\n"
+}
+
+// formatDate renders t for the _gmt date fields.
+func formatDate(t time.Time) string {
+	return t.UTC().Format(dateLayout)
+}
+
+// localDate renders t for the local date fields.
+func (s *Server) localDate(t time.Time) string {
+	return t.In(s.Location).Format(dateLayout)
+}
+
+// parseDate accepts the date formats understood by rest_parse_date. Dates
+// without an offset are read in loc.
+func parseDate(value string, loc *time.Location) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.UTC(), true
+	}
+	for _, layout := range []string{dateLayout, "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func compareTimes(a, b time.Time) int {
+	return a.Compare(b)
+}
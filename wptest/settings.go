@@ -0,0 +1,190 @@
+package wptest
+
+import (
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// settingType is the schema of a core setting.
+type settingType struct {
+	kind string
+	enum []string
+}
+
+var coreSettings = map[string]settingType{
+	"title":                  {kind: "string"},
+	"description":            {kind: "string"},
+	"url":                    {kind: "string"},
+	"email":                  {kind: "string"},
+	"timezone":               {kind: "string"},
+	"date_format":            {kind: "string"},
+	"time_format":            {kind: "string"},
+	"start_of_week":          {kind: "integer"},
+	"language":               {kind: "string"},
+	"use_smilies":            {kind: "boolean"},
+	"default_category":       {kind: "integer"},
+	"default_post_format":    {kind: "string"},
+	"posts_per_page":         {kind: "integer"},
+	"show_on_front":          {kind: "string", enum: []string{"posts", "page"}},
+	"page_on_front":          {kind: "integer"},
+	"page_for_posts":         {kind: "integer"},
+	"default_ping_status":    {kind: "string", enum: []string{"open", "closed"}},
+	"default_comment_status": {kind: "string", enum: []string{"open", "closed"}},
+	"site_logo":              {kind: "integer"},
+	"site_icon":              {kind: "integer"},
+}
+
+// RegisterSetting adds a setting to /wp/v2/settings with its default
+// value, like register_setting with show_in_rest.
+func (s *Server) RegisterSetting(name string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[name] = value
+}
+
+func (s *Server) routeSettings(req *request, rest []string) (*response, *apiError) {
+	if len(rest) > 0 {
+		return nil, errNoRoute()
+	}
+
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_forbidden", "Sorry, you are not allowed to do that.")
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return ok(s.renderSettings()), nil
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return s.updateSettings(req)
+	}
+
+	return nil, errNoRoute()
+}
+
+// updateSettings validates every setting in the request before storing
+// any, ignoring the ones that are not registered. A null value resets the
+// setting to its default.
+func (s *Server) updateSettings(req *request) (*response, *apiError) {
+	values := map[string]any{}
+	for name, value := range req.body {
+		schema, core := coreSettings[name]
+		if _, registered := s.settings[name]; !core && !registered {
+			continue
+		}
+		if value == nil || !core {
+			values[name] = value
+			continue
+		}
+
+		valid, ok := validSetting(schema, value)
+		if !ok {
+			return nil, errInvalidParam(name)
+		}
+		values[name] = valid
+	}
+
+	if email, set := values["email"].(string); set && !strings.Contains(email, "@") {
+		return nil, errInvalidParam("email")
+	}
+
+	location := s.Location
+	if timezone, set := values["timezone"]; set {
+		location = time.UTC
+		if name, _ := timezone.(string); name != "" {
+			loaded, err := time.LoadLocation(name)
+			if err != nil {
+				return nil, errInvalidParam("timezone")
+			}
+			location = loaded
+		}
+	}
+
+	for name, value := range values {
+		switch name {
+		case "title":
+			s.Name, _ = value.(string)
+		case "description":
+			s.Description, _ = value.(string)
+		case "timezone":
+			s.Location = location
+		default:
+			if value == nil {
+				delete(s.options, name)
+				continue
+			}
+			s.options[name] = value
+		}
+	}
+
+	return ok(s.renderSettings()), nil
+}
+
+// validSetting coerces value to the setting's type the way the REST API
+// sanitizes arguments, reporting whether it is valid.
+func validSetting(schema settingType, value any) (any, bool) {
+	switch schema.kind {
+	case "integer":
+		switch value := value.(type) {
+		case float64:
+			return int(value), value == math.Trunc(value)
+		case string:
+			n, err := strconv.Atoi(value)
+			return n, err == nil
+		}
+	case "boolean":
+		switch value := value.(type) {
+		case bool:
+			return value, true
+		case string:
+			b, err := strconv.ParseBool(value)
+			return b, err == nil
+		}
+	case "string":
+		text, isString := value.(string)
+		if !isString || (schema.enum != nil && !slices.Contains(schema.enum, text)) {
+			return nil, false
+		}
+		return text, true
+	}
+	return nil, false
+}
+
+func (s *Server) renderSettings() map[string]any {
+	timezone := s.Location.String()
+	if timezone == "UTC" {
+		timezone = ""
+	}
+
+	data := map[string]any{
+		"title":                  s.Name,
+		"description":            s.Description,
+		"url":                    s.URL,
+		"email":                  "admin@example.org",
+		"timezone":               timezone,
+		"date_format":            "F j, Y",
+		"time_format":            "g:i a",
+		"start_of_week":          1,
+		"language":               "en_US",
+		"use_smilies":            true,
+		"default_category":       s.defaultCategory(),
+		"default_post_format":    "0",
+		"posts_per_page":         10,
+		"show_on_front":          "posts",
+		"page_on_front":          0,
+		"page_for_posts":         0,
+		"default_ping_status":    "open",
+		"default_comment_status": "open",
+		"site_logo":              0,
+		"site_icon":              0,
+	}
+	maps.Copy(data, s.settings)
+	maps.Copy(data, s.options)
+
+	return data
+}
@@ -0,0 +1,189 @@
+package wptest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type taxonomy struct {
+	slug         string
+	name         string
+	singular     string
+	restBase     string
+	hierarchical bool
+	types        []string
+}
+
+var taxonomies = []taxonomy{
+	{slug: "category", name: "Categories", singular: "Category", restBase: "categories", hierarchical: true, types: []string{"post"}},
+	{slug: "post_tag", name: "Tags", singular: "Tag", restBase: "tags", types: []string{"post"}},
+}
+
+type postType struct {
+	slug         string
+	name         string
+	singular     string
+	restBase     string
+	hierarchical bool
+	taxonomies   []string
+	supports     []string
+}
+
+var postTypes = []postType{
+	{
+		slug: "post", name: "Posts", singular: "Post", restBase: "posts",
+		taxonomies: []string{"category", "post_tag"},
+		supports:   []string{"title", "editor", "author", "thumbnail", "excerpt", "trackbacks", "custom-fields", "comments", "revisions", "post-formats"},
+	},
+	{
+		slug: "page", name: "Pages", singular: "Page", restBase: "pages", hierarchical: true,
+		taxonomies: []string{},
+		supports:   []string{"title", "editor", "author", "thumbnail", "page-attributes", "custom-fields", "comments", "revisions"},
+	},
+	{
+		slug: "attachment", name: "Media", singular: "Media", restBase: "media",
+		taxonomies: []string{},
+		supports:   []string{"title", "author", "comments"},
+	},
+}
+
+func (s *Server) routeTaxonomies(req *request, rest []string) (*response, *apiError) {
+	if req.method != http.MethodGet || len(rest) > 1 {
+		return nil, errNoRoute()
+	}
+
+	context := req.context()
+	if context == "edit" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_forbidden_context", "Sorry, you are not allowed to manage terms in this taxonomy.")
+	}
+
+	if len(rest) == 1 {
+		for _, t := range taxonomies {
+			if t.slug == rest[0] {
+				return ok(s.addFields("taxonomy", 0, renderTaxonomy(t, context))), nil
+			}
+		}
+		return nil, newError(http.StatusNotFound, "rest_taxonomy_invalid", "Invalid taxonomy.")
+	}
+
+	body := map[string]any{}
+	for _, t := range taxonomies {
+		if req.has("type") && !slices.Contains(t.types, req.str("type")) {
+			continue
+		}
+		body[t.slug] = s.addFields("taxonomy", 0, renderTaxonomy(t, context))
+	}
+
+	return ok(body), nil
+}
+
+func renderTaxonomy(t taxonomy, context string) map[string]any {
+	data := map[string]any{
+		"name":           t.name,
+		"slug":           t.slug,
+		"description":    "",
+		"hierarchical":   t.hierarchical,
+		"types":          t.types,
+		"rest_base":      t.restBase,
+		"rest_namespace": "wp/v2",
+	}
+
+	if context == "edit" {
+		data["capabilities"] = map[string]string{
+			"manage_terms": "manage_categories",
+			"edit_terms":   "manage_categories",
+			"delete_terms": "manage_categories",
+			"assign_terms": "edit_posts",
+		}
+		data["labels"] = map[string]string{
+			"name":          t.name,
+			"singular_name": t.singular,
+			"all_items":     "All " + t.name,
+			"edit_item":     "Edit " + t.singular,
+			"menu_name":     t.name,
+		}
+		data["show_cloud"] = !t.hierarchical
+		data["visibility"] = map[string]bool{
+			"public":             true,
+			"publicly_queryable": true,
+			"show_ui":            true,
+			"show_in_menu":       true,
+			"show_in_nav_menus":  true,
+			"show_in_quick_edit": true,
+			"show_admin_column":  true,
+		}
+	}
+
+	return data
+}
+
+func (s *Server) routeTypes(req *request, rest []string) (*response, *apiError) {
+	if req.method != http.MethodGet || len(rest) > 1 {
+		return nil, errNoRoute()
+	}
+
+	context := req.context()
+	if context == "edit" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_view", "Sorry, you are not allowed to edit posts in this post type.")
+	}
+
+	if len(rest) == 1 {
+		for _, t := range postTypes {
+			if t.slug == rest[0] {
+				return ok(s.addFields("type", 0, renderType(t, context))), nil
+			}
+		}
+		return nil, newError(http.StatusNotFound, "rest_type_invalid", "Invalid post type.")
+	}
+
+	body := map[string]any{}
+	for _, t := range postTypes {
+		body[t.slug] = s.addFields("type", 0, renderType(t, context))
+	}
+
+	return ok(body), nil
+}
+
+func renderType(t postType, context string) map[string]any {
+	data := map[string]any{
+		"name":           t.name,
+		"slug":           t.slug,
+		"description":    "",
+		"hierarchical":   t.hierarchical,
+		"has_archive":    false,
+		"taxonomies":     t.taxonomies,
+		"rest_base":      t.restBase,
+		"rest_namespace": "wp/v2",
+	}
+
+	if context == "edit" {
+		plural := strings.ToLower(t.name)
+		data["capabilities"] = map[string]string{
+			"edit_post":     "edit_" + t.slug,
+			"read_post":     "read_" + t.slug,
+			"delete_post":   "delete_" + t.slug,
+			"edit_posts":    "edit_" + plural,
+			"publish_posts": "publish_" + plural,
+		}
+		data["labels"] = map[string]string{
+			"name":          t.name,
+			"singular_name": t.singular,
+			"add_new_item":  "Add New " + t.singular,
+			"edit_item":     "Edit " + t.singular,
+			"all_items":     "All " + t.name,
+		}
+		supports := map[string]bool{}
+		for _, feature := range t.supports {
+			supports[feature] = true
+		}
+		data["supports"] = supports
+		data["viewable"] = true
+		data["visibility"] = map[string]bool{
+			"show_ui":           true,
+			"show_in_nav_menus": true,
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,340 @@
+package wptest
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type term struct {
+	id          int
+	taxonomy    string
+	name        string
+	slug        string
+	description string
+	parent      int
+	meta        map[string]any
+}
+
+func errInvalidTerm() *apiError {
+	return newError(http.StatusNotFound, "rest_term_invalid", "Term does not exist.")
+}
+
+func (s *Server) routeTerms(req *request, taxonomy string, rest []string) (*response, *apiError) {
+	if len(rest) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return s.listTerms(req, taxonomy)
+		case http.MethodPost:
+			return s.createTerm(req, taxonomy)
+		}
+		return nil, errNoRoute()
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil || len(rest) > 1 {
+		return nil, errNoRoute()
+	}
+
+	t, found := s.terms[id]
+	if !found || t.taxonomy != taxonomy {
+		return nil, errInvalidTerm()
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		if req.context() == "edit" && req.user == nil {
+			return nil, newError(http.StatusUnauthorized, "rest_forbidden_context", "Sorry, you are not allowed to edit terms in this taxonomy.")
+		}
+		return ok(s.renderTerm(t)), nil
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return s.updateTerm(req, t)
+	case http.MethodDelete:
+		return s.deleteTerm(req, t)
+	}
+
+	return nil, errNoRoute()
+}
+
+// termCount counts the published posts assigned to the term.
+func (s *Server) termCount(t *term) int {
+	count := 0
+	for _, p := range s.posts {
+		if p.status != "publish" {
+			continue
+		}
+		if slices.Contains(p.categories, t.id) || slices.Contains(p.tags, t.id) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) listTerms(req *request, taxonomy string) (*response, *apiError) {
+	if req.context() == "edit" && req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_forbidden_context", "Sorry, you are not allowed to edit terms in this taxonomy.")
+	}
+
+	search := strings.ToLower(req.str("search"))
+	slugs := req.list("slug")
+	include := req.ints("include")
+	exclude := req.ints("exclude")
+
+	var assigned []int
+	if req.has("post") {
+		p := s.posts[req.int("post")]
+		if p == nil {
+			return nil, newError(http.StatusBadRequest, "rest_post_invalid_id", "Invalid post ID.")
+		}
+		assigned = p.categories
+		if taxonomy == "post_tag" {
+			assigned = p.tags
+		}
+	}
+
+	items := []*term{}
+	for _, t := range s.terms {
+		if t.taxonomy != taxonomy {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(t.name+" "+t.slug), search) {
+			continue
+		}
+		if len(slugs) > 0 && !slices.Contains(slugs, t.slug) {
+			continue
+		}
+		if len(include) > 0 && !slices.Contains(include, t.id) {
+			continue
+		}
+		if slices.Contains(exclude, t.id) {
+			continue
+		}
+		if req.has("parent") && t.parent != req.int("parent") {
+			continue
+		}
+		if req.has("post") && !slices.Contains(assigned, t.id) {
+			continue
+		}
+		if req.bool("hide_empty") && s.termCount(t) == 0 {
+			continue
+		}
+		items = append(items, t)
+	}
+
+	if err := order(req, items, "name", map[string]func(a, b *term) int{
+		"id":            func(a, b *term) int { return cmp.Compare(a.id, b.id) },
+		"include":       func(a, b *term) int { return cmp.Compare(indexOf(include, a.id), indexOf(include, b.id)) },
+		"name":          func(a, b *term) int { return strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name)) },
+		"slug":          func(a, b *term) int { return strings.Compare(a.slug, b.slug) },
+		"include_slugs": func(a, b *term) int { return cmp.Compare(indexOf(slugs, a.slug), indexOf(slugs, b.slug)) },
+		"term_group":    func(a, b *term) int { return 0 },
+		"description":   func(a, b *term) int { return strings.Compare(a.description, b.description) },
+		"count":         func(a, b *term) int { return cmp.Compare(s.termCount(a), s.termCount(b)) },
+	}); err != nil {
+		return nil, err
+	}
+
+	page, headers, apiErr := paginate(req, items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	body := []map[string]any{}
+	for _, t := range page {
+		body = append(body, s.renderTerm(t))
+	}
+
+	return &response{status: http.StatusOK, headers: headers, body: body}, nil
+}
+
+func (s *Server) createTerm(req *request, taxonomy string) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_create", "Sorry, you are not allowed to create terms in this taxonomy.")
+	}
+
+	if !req.has("name") {
+		return nil, errMissingParam("name")
+	}
+
+	t := &term{taxonomy: taxonomy, meta: map[string]any{}}
+	if err := s.applyTerm(req, t); err != nil {
+		return nil, err
+	}
+
+	if t.name == "" {
+		return nil, newError(http.StatusBadRequest, "empty_term_name", "A name is required for this term.")
+	}
+
+	if t.slug == "" {
+		t.slug = sanitizeTitle(t.name)
+	}
+	if err := s.checkTerm(t); err != nil {
+		return nil, err
+	}
+
+	t.id = s.nextID()
+	s.terms[t.id] = t
+
+	return created(s.renderTerm(t)), nil
+}
+
+func (s *Server) updateTerm(req *request, t *term) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_update", "Sorry, you are not allowed to edit this term.")
+	}
+
+	updated := *t
+	if err := s.applyTerm(req, &updated); err != nil {
+		return nil, err
+	}
+	if err := s.checkTerm(&updated); err != nil {
+		return nil, err
+	}
+
+	*t = updated
+	return ok(s.renderTerm(t)), nil
+}
+
+func (s *Server) deleteTerm(req *request, t *term) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_delete", "Sorry, you are not allowed to delete this term.")
+	}
+
+	if !req.bool("force") {
+		return nil, newError(http.StatusNotImplemented, "rest_trash_not_supported", "Terms do not support trashing. Set 'force' to true to delete.")
+	}
+
+	if t.taxonomy == "category" && t.id == s.defaultCategory() {
+		return nil, newError(http.StatusForbidden, "rest_cannot_delete", "Sorry, you are not allowed to delete this term.")
+	}
+
+	previous := s.renderTerm(t)
+	delete(s.terms, t.id)
+
+	for _, p := range s.posts {
+		p.categories = without(p.categories, t.id)
+		p.tags = without(p.tags, t.id)
+		if p.postType == "post" && len(p.categories) == 0 {
+			p.categories = []int{s.defaultCategory()}
+		}
+	}
+	for _, child := range s.terms {
+		if child.parent == t.id {
+			child.parent = t.parent
+		}
+	}
+
+	return ok(map[string]any{"deleted": true, "previous": previous}), nil
+}
+
+func (s *Server) applyTerm(req *request, t *term) *apiError {
+	if req.has("name") {
+		t.name = strings.TrimSpace(req.str("name"))
+	}
+	if req.has("slug") {
+		t.slug = sanitizeTitle(req.str("slug"))
+	}
+	if req.has("description") {
+		t.description = req.str("description")
+	}
+	if req.has("parent") {
+		if t.taxonomy != "category" {
+			return errInvalidParam("parent")
+		}
+		parent := req.int("parent")
+		if parent != 0 {
+			if other, found := s.terms[parent]; !found || other.taxonomy != t.taxonomy {
+				return newError(http.StatusBadRequest, "rest_term_invalid", "Parent term does not exist.")
+			}
+		}
+		t.parent = parent
+	}
+	if req.has("meta") {
+		meta, valid := req.body["meta"].(map[string]any)
+		if !valid {
+			return errInvalidParam("meta")
+		}
+		if t.meta == nil {
+			t.meta = map[string]any{}
+		}
+		for key, value := range meta {
+			if value == nil {
+				delete(t.meta, key)
+				continue
+			}
+			t.meta[key] = value
+		}
+	}
+	return nil
+}
+
+// checkTerm enforces the uniqueness rules of wp_insert_term and wp_update_term.
+func (s *Server) checkTerm(t *term) *apiError {
+	for _, other := range s.terms {
+		if other.id == t.id || other.taxonomy != t.taxonomy {
+			continue
+		}
+		if other.slug == t.slug {
+			return newError(http.StatusBadRequest, "duplicate_term_slug", "The slug “"+t.slug+"” is already in use by another term.")
+		}
+		if strings.EqualFold(other.name, t.name) && other.parent == t.parent {
+			apiErr := newError(http.StatusBadRequest, "term_exists", "A term with the name provided already exists with this parent.")
+			apiErr.data = map[string]any{"term_id": other.id}
+			return apiErr
+		}
+	}
+	return nil
+}
+
+func (s *Server) defaultCategory() int {
+	for _, t := range s.terms {
+		if t.taxonomy == "category" && t.slug == "uncategorized" {
+			return t.id
+		}
+	}
+	return 0
+}
+
+func (s *Server) renderTerm(t *term) map[string]any {
+	base := "category"
+	if t.taxonomy == "post_tag" {
+		base = "tag"
+	}
+
+	var meta any = []any{}
+	if len(t.meta) > 0 {
+		meta = t.meta
+	}
+
+	data := map[string]any{
+		"id":          t.id,
+		"count":       s.termCount(t),
+		"description": t.description,
+		"link":        s.URL + "/" + base + "/" + t.slug + "/",
+		"name":        t.name,
+		"slug":        t.slug,
+		"taxonomy":    t.taxonomy,
+		"meta":        meta,
+	}
+
+	if t.taxonomy == "category" {
+		data["parent"] = t.parent
+	}
+
+	return s.addFields(t.taxonomy, t.id, data)
+}
+
+func without(ids []int, id int) []int {
+	if !slices.Contains(ids, id) {
+		return ids
+	}
+	kept := []int{}
+	for _, v := range ids {
+		if v != id {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,180 @@
+package wptest
+
+import (
+	"net/http"
+	"slices"
+)
+
+type theme struct {
+	stylesheet  string
+	template    string
+	name        string
+	description string
+	version     string
+	requiresPHP string
+	requiresWP  string
+	blockTheme  bool
+	supports    map[string]any
+}
+
+// installedThemes are the themes of a fresh install, in the order
+// wp_get_themes sorts them.
+var installedThemes = []theme{
+	{
+		stylesheet:  "twentytwentyfive",
+		template:    "twentytwentyfive",
+		name:        "Twenty Twenty-Five",
+		description: "Twenty Twenty-Five emphasizes simplicity and adaptability.",
+		version:     "1.2",
+		requiresPHP: "7.2",
+		requiresWP:  "6.7",
+		blockTheme:  true,
+		supports: map[string]any{
+			"align-wide":        true,
+			"block-templates":   true,
+			"editor-styles":     true,
+			"post-thumbnails":   true,
+			"responsive-embeds": true,
+			"title-tag":         false,
+			"wp-block-styles":   true,
+			"html5":             []string{"comment-form", "comment-list", "search-form", "gallery", "caption", "style", "script"},
+		},
+	},
+	{
+		stylesheet:  "twentytwentyfour",
+		template:    "twentytwentyfour",
+		name:        "Twenty Twenty-Four",
+		description: "Twenty Twenty-Four is designed to be flexible, versatile and applicable to any website.",
+		version:     "1.3",
+		requiresPHP: "7.0",
+		requiresWP:  "6.4",
+		blockTheme:  true,
+		supports: map[string]any{
+			"align-wide":        true,
+			"block-templates":   true,
+			"editor-styles":     true,
+			"post-thumbnails":   true,
+			"responsive-embeds": true,
+			"title-tag":         false,
+			"wp-block-styles":   false,
+			"html5":             []string{"comment-form", "comment-list", "search-form", "gallery", "caption", "style", "script"},
+		},
+	},
+	{
+		stylesheet:  "twentytwentyone",
+		template:    "twentytwentyone",
+		name:        "Twenty Twenty-One",
+		description: "Twenty Twenty-One is a blank canvas for your ideas and it makes the block editor your best brush.",
+		version:     "2.5",
+		requiresPHP: "5.6",
+		requiresWP:  "5.3",
+		supports: map[string]any{
+			"align-wide":           true,
+			"automatic-feed-links": true,
+			"custom-logo":          map[string]any{"width": 300, "height": 100, "flex-width": true, "flex-height": true},
+			"editor-styles":        true,
+			"post-thumbnails":      true,
+			"responsive-embeds":    true,
+			"title-tag":            true,
+			"wp-block-styles":      true,
+			"html5":                []string{"comment-form", "comment-list", "gallery", "caption", "style", "script", "navigation-widgets"},
+		},
+	},
+}
+
+func (s *Server) routeThemes(req *request, rest []string) (*response, *apiError) {
+	if len(rest) > 1 {
+		return nil, errNoRoute()
+	}
+
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_view_themes", "Sorry, you are not allowed to view themes.")
+	}
+
+	switch {
+	case req.method == http.MethodGet && len(rest) == 0:
+		return s.listThemes(req)
+	case req.method == http.MethodGet:
+		t, found := findTheme(rest[0])
+		if !found {
+			return nil, newError(http.StatusNotFound, "rest_theme_not_found", "Theme not found.")
+		}
+		return ok(s.renderTheme(t)), nil
+	case req.method == http.MethodPost && len(rest) == 1 && s.AllowThemeActivation:
+		return s.activateTheme(req, rest[0])
+	}
+
+	return nil, errNoRoute()
+}
+
+func (s *Server) listThemes(req *request) (*response, *apiError) {
+	statuses := req.list("status")
+	for _, status := range statuses {
+		if status != "active" && status != "inactive" {
+			return nil, errInvalidParam("status")
+		}
+	}
+
+	body := []map[string]any{}
+	for _, t := range installedThemes {
+		if len(statuses) > 0 && !slices.Contains(statuses, s.themeStatus(t)) {
+			continue
+		}
+		body = append(body, s.renderTheme(t))
+	}
+
+	return ok(body), nil
+}
+
+func (s *Server) activateTheme(req *request, stylesheet string) (*response, *apiError) {
+	t, found := findTheme(stylesheet)
+	if !found {
+		return nil, newError(http.StatusNotFound, "rest_theme_not_found", "Theme not found.")
+	}
+	if req.str("status") != "active" {
+		return nil, errInvalidParam("status")
+	}
+
+	s.stylesheet = t.stylesheet
+	return ok(s.renderTheme(t)), nil
+}
+
+func findTheme(stylesheet string) (theme, bool) {
+	for _, t := range installedThemes {
+		if t.stylesheet == stylesheet {
+			return t, true
+		}
+	}
+	return theme{}, false
+}
+
+func (s *Server) themeStatus(t theme) string {
+	if t.stylesheet == s.stylesheet {
+		return "active"
+	}
+	return "inactive"
+}
+
+func (s *Server) renderTheme(t theme) map[string]any {
+	root := s.URL + "/wp-content/themes/" + t.stylesheet
+	return map[string]any{
+		"stylesheet":     t.stylesheet,
+		"template":       t.template,
+		"name":           map[string]string{"raw": t.name, "rendered": t.name},
+		"description":    map[string]string{"raw": t.description, "rendered": t.description},
+		"author":         map[string]string{"raw": "the WordPress team", "rendered": `<a href="https://wordpress.org">the WordPress team</a>`},
+		"author_uri":     map[string]string{"raw": "https://wordpress.org", "rendered": "https://wordpress.org"},
+		"theme_uri":      map[string]string{"raw": "https://wordpress.org/themes/" + t.stylesheet + "/", "rendered": "https://wordpress.org/themes/" + t.stylesheet + "/"},
+		"tags":           map[string]any{"raw": []string{"blog", "one-column"}, "rendered": "blog, one-column"},
+		"version":        t.version,
+		"requires_php":   t.requiresPHP,
+		"requires_wp":    t.requiresWP,
+		"textdomain":     t.stylesheet,
+		"screenshot":     root + "/screenshot.png",
+		"status":         s.themeStatus(t),
+		"theme_supports": t.supports,
+		"is_block_theme": t.blockTheme,
+		"stylesheet_uri": root,
+		"template_uri":   root,
+	}
+}
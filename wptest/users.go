@@ -0,0 +1,473 @@
+package wptest
+
+import (
+	"cmp"
+	"crypto/md5"
+	"encoding/hex"
+	"maps"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type user struct {
+	id          int
+	username    string
+	password    string
+	name        string
+	firstName   string
+	lastName    string
+	nickname    string
+	email       string
+	url         string
+	description string
+	slug        string
+	locale      string
+	roles       []string
+	registered  time.Time
+	meta        map[string]any
+
+	appPasswords []*appPassword
+}
+
+// roleCapabilities holds a subset of the capabilities WordPress grants the
+// default roles, enough for the who and capabilities filters.
+var roleCapabilities = map[string][]string{
+	"administrator": {"read", "edit_posts", "publish_posts", "edit_others_posts", "edit_pages", "upload_files", "moderate_comments", "manage_categories", "manage_options", "list_users", "create_users", "edit_users", "delete_users", "promote_users"},
+	"editor":        {"read", "edit_posts", "publish_posts", "edit_others_posts", "edit_pages", "upload_files", "moderate_comments", "manage_categories"},
+	"author":        {"read", "edit_posts", "publish_posts", "upload_files"},
+	"contributor":   {"read", "edit_posts"},
+	"subscriber":    {"read"},
+}
+
+func (u *user) can(capability string) bool {
+	for _, role := range u.roles {
+		if slices.Contains(roleCapabilities[role], capability) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) addUser(username, password string) int {
+	s.lastUser++
+	s.users[username] = &user{
+		id:         s.lastUser,
+		username:   username,
+		name:       username,
+		nickname:   username,
+		email:      username + "@example.org",
+		slug:       sanitizeTitle(username),
+		roles:      []string{"administrator"},
+		registered: time.Now().UTC().Truncate(time.Second),
+		meta:       map[string]any{},
+		appPasswords: []*appPassword{{
+			uuid:     newUUID(),
+			name:     "wptest",
+			password: password,
+			created:  time.Now().UTC().Truncate(time.Second),
+		}},
+	}
+	return s.lastUser
+}
+
+func (s *Server) findUser(id int) *user {
+	for _, u := range s.users {
+		if u.id == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func errInvalidUserID() *apiError {
+	return newError(http.StatusNotFound, "rest_user_invalid_id", "Invalid user ID.")
+}
+
+func (s *Server) routeUsers(req *request, rest []string) (*response, *apiError) {
+	if len(rest) == 0 {
+		switch req.method {
+		case http.MethodGet:
+			return s.listUsers(req)
+		case http.MethodPost:
+			return s.createUser(req)
+		}
+		return nil, errNoRoute()
+	}
+
+	var u *user
+	if rest[0] == "me" {
+		if req.user == nil {
+			return nil, newError(http.StatusUnauthorized, "rest_not_logged_in", "You are not currently logged in.")
+		}
+		u = req.user
+	} else {
+		id, err := strconv.Atoi(rest[0])
+		if err != nil {
+			return nil, errNoRoute()
+		}
+		if u = s.findUser(id); u == nil {
+			return nil, errInvalidUserID()
+		}
+	}
+
+	if len(rest) > 1 {
+		if rest[1] == "application-passwords" {
+			return s.routeAppPasswords(req, u, rest[2:])
+		}
+		return nil, errNoRoute()
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		context := req.context()
+		if context == "edit" && req.user == nil {
+			return nil, newError(http.StatusUnauthorized, "rest_user_cannot_view", "Sorry, you are not allowed to list users.")
+		}
+		if req.user == nil && !s.hasPublished(u) {
+			return nil, newError(http.StatusUnauthorized, "rest_user_cannot_view", "Sorry, you are not allowed to list users.")
+		}
+		return ok(s.renderUser(u, context)), nil
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return s.updateUser(req, u)
+	case http.MethodDelete:
+		return s.deleteUser(req, u)
+	}
+
+	return nil, errNoRoute()
+}
+
+// hasPublished reports whether the user authored a published post or page,
+// which makes the user visible to anonymous requests.
+func (s *Server) hasPublished(u *user) bool {
+	for _, p := range s.posts {
+		if p.author == u.id && p.status == "publish" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listUsers(req *request) (*response, *apiError) {
+	context := req.context()
+	if req.user == nil {
+		if context == "edit" {
+			return nil, newError(http.StatusUnauthorized, "rest_forbidden_context", "Sorry, you are not allowed to list users.")
+		}
+		for _, key := range []string{"roles", "capabilities", "who"} {
+			if req.has(key) {
+				return nil, newError(http.StatusUnauthorized, "rest_user_cannot_view", "Sorry, you are not allowed to filter users by "+key+".")
+			}
+		}
+	}
+
+	who := req.str("who")
+	if who != "" && who != "authors" {
+		return nil, errInvalidParam("who")
+	}
+
+	search := strings.ToLower(strings.Trim(req.str("search"), "*"))
+	slugs := req.list("slug")
+	roles := req.list("roles")
+	capabilities := req.list("capabilities")
+	include := req.ints("include")
+	exclude := req.ints("exclude")
+
+	items := []*user{}
+	for _, u := range s.users {
+		if req.user == nil && !s.hasPublished(u) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(u.username+" "+u.name+" "+u.email+" "+u.url+" "+u.slug), search) {
+			continue
+		}
+		if len(slugs) > 0 && !slices.Contains(slugs, u.slug) {
+			continue
+		}
+		if len(include) > 0 && !slices.Contains(include, u.id) {
+			continue
+		}
+		if slices.Contains(exclude, u.id) {
+			continue
+		}
+		if len(roles) > 0 && !slices.ContainsFunc(u.roles, func(role string) bool { return slices.Contains(roles, role) }) {
+			continue
+		}
+		if !allCapabilities(u, capabilities) {
+			continue
+		}
+		if who == "authors" && !u.can("edit_posts") {
+			continue
+		}
+		items = append(items, u)
+	}
+
+	if err := order(req, items, "name", map[string]func(a, b *user) int{
+		"id":              func(a, b *user) int { return cmp.Compare(a.id, b.id) },
+		"include":         func(a, b *user) int { return cmp.Compare(indexOf(include, a.id), indexOf(include, b.id)) },
+		"name":            func(a, b *user) int { return strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name)) },
+		"registered_date": func(a, b *user) int { return cmp.Or(compareTimes(a.registered, b.registered), cmp.Compare(a.id, b.id)) },
+		"slug":            func(a, b *user) int { return strings.Compare(a.slug, b.slug) },
+		"include_slugs":   func(a, b *user) int { return cmp.Compare(indexOf(slugs, a.slug), indexOf(slugs, b.slug)) },
+		"email":           func(a, b *user) int { return strings.Compare(a.email, b.email) },
+		"url":             func(a, b *user) int { return strings.Compare(a.url, b.url) },
+	}); err != nil {
+		return nil, err
+	}
+
+	page, headers, apiErr := paginate(req, items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	body := []map[string]any{}
+	for _, u := range page {
+		body = append(body, s.renderUser(u, context))
+	}
+
+	return &response{status: http.StatusOK, headers: headers, body: body}, nil
+}
+
+func allCapabilities(u *user, capabilities []string) bool {
+	for _, capability := range capabilities {
+		if !u.can(capability) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) createUser(req *request) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_create_user", "Sorry, you are not allowed to create new users.")
+	}
+
+	if req.has("id") {
+		return nil, newError(http.StatusBadRequest, "rest_user_exists", "Cannot create existing user.")
+	}
+
+	missing := []string{}
+	for _, key := range []string{"username", "email", "password"} {
+		if !req.has(key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errMissingParam(missing...)
+	}
+
+	username := strings.TrimSpace(req.str("username"))
+	if username == "" {
+		return nil, errInvalidParam("username")
+	}
+	if _, taken := s.users[username]; taken {
+		return nil, newError(http.StatusBadRequest, "existing_user_login", "Sorry, that username already exists!")
+	}
+
+	u := &user{
+		username:   username,
+		name:       username,
+		nickname:   username,
+		slug:       sanitizeTitle(username),
+		roles:      []string{"subscriber"},
+		registered: time.Now().UTC().Truncate(time.Second),
+		meta:       map[string]any{},
+	}
+	if err := s.applyUser(req, u); err != nil {
+		return nil, err
+	}
+
+	s.lastUser++
+	u.id = s.lastUser
+	s.users[u.username] = u
+
+	return created(s.renderUser(u, "edit")), nil
+}
+
+func (s *Server) updateUser(req *request, u *user) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_cannot_edit", "Sorry, you are not allowed to edit this user.")
+	}
+
+	if req.has("username") && req.str("username") != u.username {
+		return nil, newError(http.StatusBadRequest, "rest_user_invalid_argument", "Username isn't editable.")
+	}
+
+	updated := *u
+	if err := s.applyUser(req, &updated); err != nil {
+		return nil, err
+	}
+
+	*u = updated
+	return ok(s.renderUser(u, "edit")), nil
+}
+
+func (s *Server) applyUser(req *request, u *user) *apiError {
+	if req.has("email") {
+		email := strings.TrimSpace(req.str("email"))
+		if _, err := mail.ParseAddress(email); err != nil {
+			return errInvalidParam("email")
+		}
+		for _, other := range s.users {
+			if other != u && other.username != u.username && strings.EqualFold(other.email, email) {
+				return newError(http.StatusBadRequest, "existing_user_email", "Sorry, that email address is already used!")
+			}
+		}
+		u.email = email
+	}
+
+	if req.has("roles") {
+		roles := req.list("roles")
+		for _, role := range roles {
+			if _, known := roleCapabilities[role]; !known {
+				return newError(http.StatusBadRequest, "rest_user_invalid_role", "The role "+role+" does not exist.")
+			}
+		}
+		u.roles = roles
+	}
+
+	if req.has("slug") {
+		slug := sanitizeTitle(req.str("slug"))
+		for _, other := range s.users {
+			if other != u && other.username != u.username && other.slug == slug {
+				return newError(http.StatusBadRequest, "rest_user_invalid_slug", "Slug is already in use.")
+			}
+		}
+		u.slug = slug
+	}
+
+	for key, field := range map[string]*string{
+		"password":    &u.password,
+		"name":        &u.name,
+		"first_name":  &u.firstName,
+		"last_name":   &u.lastName,
+		"nickname":    &u.nickname,
+		"url":         &u.url,
+		"description": &u.description,
+		"locale":      &u.locale,
+	} {
+		if req.has(key) {
+			*field = req.str(key)
+		}
+	}
+
+	if req.has("meta") {
+		meta, valid := req.body["meta"].(map[string]any)
+		if !valid {
+			return errInvalidParam("meta")
+		}
+		u.meta = maps.Clone(u.meta)
+		for key, value := range meta {
+			if value == nil {
+				delete(u.meta, key)
+				continue
+			}
+			u.meta[key] = value
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) deleteUser(req *request, u *user) (*response, *apiError) {
+	if req.user == nil {
+		return nil, newError(http.StatusUnauthorized, "rest_user_cannot_delete", "Sorry, you are not allowed to delete this user.")
+	}
+
+	if !req.bool("force") {
+		return nil, newError(http.StatusNotImplemented, "rest_trash_not_supported", "Users do not support trashing. Set 'force' to true to delete.")
+	}
+
+	if !req.has("reassign") {
+		return nil, errMissingParam("reassign")
+	}
+
+	// reassign is a user ID, or false to delete the user's content.
+	var reassign *user
+	if value := req.str("reassign"); value != "" && value != "false" && value != "0" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errInvalidParam("reassign")
+		}
+		if reassign = s.findUser(id); reassign == nil || reassign == u {
+			return nil, newError(http.StatusBadRequest, "rest_user_invalid_reassign", "Invalid user ID for reassignment.")
+		}
+	}
+
+	previous := s.renderUser(u, "edit")
+	delete(s.users, u.username)
+
+	for id, p := range s.posts {
+		if p.author != u.id {
+			continue
+		}
+		if reassign != nil {
+			p.author = reassign.id
+			continue
+		}
+		delete(s.posts, id)
+	}
+
+	return ok(map[string]any{"deleted": true, "previous": previous}), nil
+}
+
+func (s *Server) renderUser(u *user, context string) map[string]any {
+	data := map[string]any{
+		"id":          u.id,
+		"name":        u.name,
+		"url":         u.url,
+		"description": u.description,
+		"link":        s.URL + "/author/" + u.slug + "/",
+		"slug":        u.slug,
+		"avatar_urls": avatarURLs(u.email),
+	}
+
+	if context == "embed" {
+		return data
+	}
+
+	meta := map[string]any{}
+	for key, value := range u.meta {
+		meta[key] = value
+	}
+	data["meta"] = meta
+
+	if context == "edit" {
+		capabilities := map[string]bool{}
+		extra := map[string]bool{}
+		for _, role := range u.roles {
+			extra[role] = true
+			capabilities[role] = true
+			for _, capability := range roleCapabilities[role] {
+				capabilities[capability] = true
+			}
+		}
+
+		data["username"] = u.username
+		data["first_name"] = u.firstName
+		data["last_name"] = u.lastName
+		data["email"] = u.email
+		data["locale"] = cmp.Or(u.locale, "en_US")
+		data["nickname"] = u.nickname
+		data["registered_date"] = u.registered.Format(time.RFC3339)
+		data["roles"] = u.roles
+		data["capabilities"] = capabilities
+		data["extra_capabilities"] = extra
+	}
+
+	return s.addFields("user", u.id, data)
+}
+
+// avatarURLs returns the Gravatar URLs WordPress reports for an email.
+func avatarURLs(email string) map[string]string {
+	hash := md5.Sum([]byte(strings.ToLower(strings.TrimSpace(email))))
+	avatar := "https://secure.gravatar.com/avatar/" + hex.EncodeToString(hash[:]) + "?d=mm&r=g&s="
+	return map[string]string{
+		"24": avatar + "24",
+		"48": avatar + "48",
+		"96": avatar + "96",
+	}
+}